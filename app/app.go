@@ -0,0 +1,312 @@
+// Package app builds the swaggpt command line application.
+//
+// A custom build can register additional frameworks before running it:
+//
+//	func main() {
+//		framework.Register(myrouter.Framework{})
+//		if err := app.New().Run(os.Args); err != nil {
+//			log.Fatal(err)
+//		}
+//	}
+package app
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/insectkorea/swagGPT/internal/api"
+	"github.com/insectkorea/swagGPT/internal/config"
+	"github.com/insectkorea/swagGPT/internal/handler"
+	"github.com/insectkorea/swagGPT/internal/mapping"
+	"github.com/insectkorea/swagGPT/internal/matcher"
+	"github.com/insectkorea/swagGPT/internal/model"
+	"github.com/insectkorea/swagGPT/internal/scanner"
+	"github.com/sirupsen/logrus"
+
+	"github.com/urfave/cli/v2"
+)
+
+// New returns the swaggpt application.
+func New() *cli.App {
+	return &cli.App{
+		Name:  "Swagger Comment Adder",
+		Usage: "Add Swagger comments to Gin handler functions",
+		Commands: []*cli.Command{
+			{
+				Name:  "add-comments",
+				Usage: "Add Swagger comments to handler functions",
+				Action: func(c *cli.Context) error {
+					dryRun := c.Bool("dry-run")
+					model := c.String("model")
+					skipPrompt := c.Bool("yes")
+
+					apiKey := os.Getenv("OPENAI_API_KEY")
+					if apiKey == "" {
+						return cli.Exit("OpenAI API key is required", 1)
+					}
+
+					client := api.NewOpenAIClient(apiKey)
+
+					src, err := loadSources(c)
+					if err != nil {
+						return cli.Exit(err.Error(), 1)
+					}
+					match := matcher.Options{TopK: c.Int("candidates"), MinScore: c.Float64("min-score")}
+
+					reader := bufio.NewReader(c.App.Reader)
+					if c.Bool("interactive") {
+						if err := disambiguate(c, reader, src, match); err != nil {
+							return cli.Exit(err.Error(), 1)
+						}
+					}
+					match.Unrouted = src.unrouted
+
+					// Estimate total tokens and cost
+					totalTokens := handler.EstimateTotalTokens(src.files, src.routes, src.opts, match)
+
+					logrus.Infof(
+						`
+Estimated total tokens: %d
+Estimated cost (approx): $%.2f
+This approximation is based on gpt-4o model($5.00 / 1M tokens). 
+Please check OpenAI's pricing for other models.`, totalTokens, float64(totalTokens)/1000000*5)
+					// GPT-4o cost $5.00 / 1M tokens
+					// Prompt user for confirmation unless --yes flag is provided
+					if !skipPrompt {
+						fmt.Fprint(c.App.Writer, "Do you want to proceed? (y/N): ")
+						response, err := reader.ReadString('\n')
+						if err != nil {
+							return err
+						}
+						response = strings.TrimSpace(strings.ToLower(response))
+						if response != "y" && response != "yes" {
+							fmt.Fprintln(c.App.Writer, "Operation aborted.")
+							return nil
+						}
+					}
+
+					err = handler.ProcessFiles(src.files, client, dryRun, model, src.routes, src.opts, match)
+					if err != nil {
+						return cli.Exit(err.Error(), 1)
+					}
+
+					return nil
+				},
+				Flags: append(sourceFlags(),
+					&cli.BoolFlag{
+						Name:  "dry-run",
+						Usage: "Preview changes without writing to files",
+					},
+					&cli.StringFlag{
+						Name:  "model",
+						Usage: "OpenAI model to use",
+						Value: "gpt-4o",
+					},
+					&cli.IntFlag{
+						Name:  "candidates",
+						Usage: "Maximum number of candidate routes suggested for a handler no route is bound to, or 0 for no limit",
+						Value: matcher.DefaultOptions.TopK,
+					},
+					&cli.Float64Flag{
+						Name:  "min-score",
+						Usage: "Minimum score, from 0 to 1, of the candidate routes suggested for a handler no route is bound to",
+						Value: matcher.DefaultOptions.MinScore,
+					},
+					&cli.BoolFlag{
+						Name:  "interactive",
+						Usage: "Pick the routes of the handlers whose route is ambiguous or missing before any API call, and save them to the mapping file",
+					},
+					&cli.BoolFlag{
+						Name:  "yes",
+						Usage: "Skip confirmation prompt",
+					},
+				),
+			},
+			routesCommand(),
+			evalMatcherCommand(),
+		},
+	}
+}
+
+// sourceFlags returns the flags selecting the files, handlers and routes of
+// the scanned code.
+func sourceFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.StringFlag{
+			Name:     "dir",
+			Usage:    "Directory to scan for Go files",
+			Required: true,
+		},
+		&cli.StringSliceFlag{
+			Name:  "include",
+			Usage: "Only scan files matching the glob, relative to --dir (repeatable)",
+		},
+		&cli.StringSliceFlag{
+			Name:  "exclude",
+			Usage: "Skip files matching the glob, relative to --dir, or scan them again if it starts with ! (repeatable)",
+		},
+		&cli.BoolFlag{
+			Name:  "unexported",
+			Usage: "Also document unexported handlers that routes are registered with",
+		},
+		&cli.StringFlag{
+			Name:  "receiver",
+			Usage: "Only document methods of the given receiver type, e.g. UserHandler",
+		},
+		&cli.StringFlag{
+			Name:  "config",
+			Usage: "Config file declaring custom handler signatures and wrappers (default: .swaggpt.yaml in --dir)",
+		},
+		&cli.StringFlag{
+			Name:  "route-file",
+			Usage: "File to read routes from instead of the module containing --dir",
+		},
+		&cli.StringSliceFlag{
+			Name:  "routes-from",
+			Usage: "Route dump of a running server, either Gin's [GIN-debug] output or the JSON of Echo's e.Routes() (repeatable)",
+		},
+		&cli.StringFlag{
+			Name:  "mapping",
+			Usage: "Mapping file pinning handlers to their routes (default: swaggpt.routes.yaml in --dir)",
+		},
+		&cli.BoolFlag{
+			Name:  "extract-routes",
+			Usage: "Extract routes from the source along with those of --routes-from",
+			Value: true,
+		},
+	}
+}
+
+// sources are the scanned files, handlers and routes.
+type sources struct {
+	// files are parsed once, with the handlers of any receiver.
+	files []handler.File
+	// scanned are the handlers in files, and handlers those selected by opts.
+	scanned  []matcher.Handler
+	handlers []matcher.Handler
+	routes   []model.Route
+	opts     scanner.Options
+	// unrouted are the handlers the mapping file pins to no route.
+	unrouted []matcher.Handler
+	mapping  *mapping.File
+	// mappingPath is the path of the mapping file.
+	mappingPath string
+	basePath    string
+}
+
+// loadSources applies the config of the scanned directory and returns the
+// files to scan, the options selecting handlers, and the routes with those of
+// the mapping file applied.
+func loadSources(c *cli.Context) (*sources, error) {
+	src := &sources{
+		opts: scanner.Options{
+			Unexported: c.Bool("unexported"),
+			Receiver:   c.String("receiver"),
+		},
+	}
+
+	dir := c.String("dir")
+	if dir == "" {
+		return nil, fmt.Errorf("directory is required")
+	}
+
+	cfg, err := config.Load(c.String("config"), dir)
+	if err != nil {
+		return nil, err
+	}
+	cfg.Apply()
+
+	filter, err := scanner.LoadFilter(dir, c.StringSlice("include"), c.StringSlice("exclude"))
+	if err != nil {
+		return nil, err
+	}
+
+	paths, err := scanner.ScanDir(dir, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	routes, err := loadRoutes(c, dir)
+	if err != nil {
+		return nil, err
+	}
+	cfg.Annotate(routes)
+
+	src.mappingPath = mapping.Path(c.String("mapping"), dir)
+	src.mapping, err = mapping.Load(src.mappingPath)
+	if err != nil {
+		return nil, err
+	}
+	// Pins apply to the handlers of any receiver, so that a pinned route is
+	// not bound to another handler and no pin is reported as stale because
+	// of --receiver
+	src.files = handler.ParseFiles(paths, routes, scanner.Options{Unexported: src.opts.Unexported})
+	src.scanned = handler.ScanHandlers(src.files, scanner.Options{})
+	src.handlers = handler.ScanHandlers(src.files, src.opts)
+	matcher.ResolveHandlers(routes, src.scanned)
+	// Pinned handlers may be in the files the filter skips
+	var warnings []string
+	if !filter.Narrows() {
+		warnings = src.mapping.CheckHandlers(src.scanned)
+	}
+	for _, warning := range append(warnings, src.mapping.CheckRoutes(routes)...) {
+		logrus.Warnf("%s: %s", src.mappingPath, warning)
+	}
+
+	src.basePath = cfg.BasePath
+	if src.basePath == "" {
+		src.basePath = scanner.FindBasePath(paths)
+	}
+	src.routes = routes
+	src.applyMapping()
+	return src, nil
+}
+
+// applyMapping replaces the routes of the handlers pinned by the mapping file
+// with the routes they are pinned to, which take their tags and security.
+func (src *sources) applyMapping() {
+	src.routes, src.unrouted = src.mapping.Apply(src.scanned, src.routes)
+	matcher.SetRouterPaths(src.routes, src.basePath)
+}
+
+// loadRoutes returns the routes of the route dumps given with --routes-from,
+// followed by the other routes extracted from the source.
+func loadRoutes(c *cli.Context, dir string) ([]model.Route, error) {
+	var dumped []model.Route
+	for _, path := range c.StringSlice("routes-from") {
+		routes, err := handler.LoadRouteDump(path)
+		if err != nil {
+			return nil, err
+		}
+		dumped = append(dumped, routes...)
+	}
+	if !c.Bool("extract-routes") {
+		return dumped, nil
+	}
+
+	extracted, err := extractRoutes(dir, c.String("route-file"))
+	if err != nil {
+		return nil, err
+	}
+	return handler.MergeRoutes(dumped, extracted), nil
+}
+
+// extractRoutes extracts the routes from routeFile if it is set, or else from
+// the module containing dir. Handlers are still matched to routes by name if
+// the module cannot be loaded.
+func extractRoutes(dir string, routeFile string) ([]model.Route, error) {
+	if routeFile != "" {
+		contextHandler := &handler.ContextFileHandler{}
+		return contextHandler.ExtractRoutes(routeFile)
+	}
+
+	routes, err := handler.ModuleRoutes(dir)
+	if err != nil {
+		logrus.Warnf("Failed to extract routes: %v", err)
+		return nil, nil
+	}
+	logrus.Infof("Found %d routes", len(routes))
+	return routes, nil
+}
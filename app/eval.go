@@ -0,0 +1,77 @@
+package app
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/insectkorea/swagGPT/internal/evaluation"
+	"github.com/insectkorea/swagGPT/internal/matcher"
+	"github.com/urfave/cli/v2"
+)
+
+// evalMatcherCommand returns the command measuring the matcher against
+// fixtures, which needs no API key.
+func evalMatcherCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "eval-matcher",
+		Usage:     "Measure how well routes are guessed from handler names, against fixtures of handlers and the routes they serve",
+		ArgsUsage: "FIXTURE...",
+		Action: func(c *cli.Context) error {
+			if c.NArg() == 0 {
+				return cli.Exit("at least one fixture file or directory is required", 1)
+			}
+			fixtures, err := evaluation.LoadFixtures(c.Args().Slice())
+			if err != nil {
+				return cli.Exit(err.Error(), 1)
+			}
+
+			opts := matcher.Options{TopK: c.Int("candidates"), MinScore: c.Float64("min-score")}
+			report := evaluation.Evaluate(fixtures, evaluation.DefaultMatcher(opts), opts.TopK)
+			if err := writeReport(c.App.Writer, report, c.String("format")); err != nil {
+				return cli.Exit(err.Error(), 1)
+			}
+			return nil
+		},
+		Flags: []cli.Flag{
+			&cli.IntFlag{
+				Name:  "candidates",
+				Usage: "Maximum number of candidate routes of a handler, or 0 for no limit",
+				Value: matcher.DefaultOptions.TopK,
+			},
+			&cli.Float64Flag{
+				Name:  "min-score",
+				Usage: "Minimum score, from 0 to 1, of the candidate routes of a handler",
+				Value: matcher.DefaultOptions.MinScore,
+			},
+			&cli.StringFlag{
+				Name:  "format",
+				Usage: "Output format: text or json",
+				Value: "text",
+			},
+		},
+	}
+}
+
+// writeReport writes report to w in format.
+func writeReport(w io.Writer, report *evaluation.Report, format string) error {
+	switch format {
+	case "text":
+		_, err := fmt.Fprint(w, report)
+		return err
+	case "json":
+		if report.Failures == nil {
+			report.Failures = []evaluation.Failure{}
+		}
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(struct {
+			*evaluation.Report
+			Top1Accuracy float64 `json:"top1Accuracy"`
+			TopKAccuracy float64 `json:"topKAccuracy"`
+			Precision    float64 `json:"precision"`
+			Recall       float64 `json:"recall"`
+		}{report, report.Top1Accuracy(), report.TopKAccuracy(), report.Precision(), report.Recall()})
+	}
+	return fmt.Errorf("unknown format %q: expected text or json", format)
+}
@@ -0,0 +1,50 @@
+package app
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/insectkorea/swagGPT/internal/evaluation"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriteReport(t *testing.T) {
+	report := func() *evaluation.Report {
+		return &evaluation.Report{
+			K: 3, Cases: 2, Top1: 1, TopK: 2, TruePositives: 2, FalsePositives: 2,
+			Failures: []evaluation.Failure{{Fixture: "users", Handler: "UserHandler.Delete", Expected: []string{"DELETE /users/{id}"}, Got: []string{"GET /users/{id} (0.75)", "DELETE /users/{id} (0.70)"}}},
+		}
+	}
+
+	var text bytes.Buffer
+	assert.NoError(t, writeReport(&text, report(), "text"))
+	assert.Equal(t, `Cases:          2
+Top-1 accuracy: 50.0% (1/2)
+Top-3 accuracy: 100.0% (2/2)
+Precision:      50.0% (2/4)
+Recall:         100.0% (2/2)
+
+Failed cases:
+  users: UserHandler.Delete
+    expected: DELETE /users/{id}
+    got:      GET /users/{id} (0.75), DELETE /users/{id} (0.70)
+`, text.String())
+
+	var out bytes.Buffer
+	assert.NoError(t, writeReport(&out, report(), "json"))
+	var decoded map[string]any
+	assert.NoError(t, json.Unmarshal(out.Bytes(), &decoded))
+	assert.Equal(t, 0.5, decoded["top1Accuracy"])
+	assert.Equal(t, 1.0, decoded["topKAccuracy"])
+	assert.Equal(t, 0.5, decoded["precision"])
+	assert.Equal(t, 1.0, decoded["recall"])
+	assert.Equal(t, 3.0, decoded["k"])
+	assert.Len(t, decoded["failures"], 1)
+
+	out.Reset()
+	assert.NoError(t, writeReport(&out, &evaluation.Report{}, "json"))
+	assert.Contains(t, out.String(), `"failures": []`)
+
+	assert.EqualError(t, writeReport(&bytes.Buffer{}, report(), "xml"), `unknown format "xml": expected text or json`)
+}
@@ -0,0 +1,117 @@
+package app
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/insectkorea/swagGPT/internal/handler"
+	"github.com/insectkorea/swagGPT/internal/mapping"
+	"github.com/insectkorea/swagGPT/internal/matcher"
+	"github.com/urfave/cli/v2"
+)
+
+// disambiguate asks which routes serve each handler of src whose route is
+// ambiguous or missing, pins the answers in the mapping file and applies them
+// to src.
+func disambiguate(c *cli.Context, reader *bufio.Reader, src *sources, match matcher.Options) error {
+	match.Unrouted = src.unrouted
+	ambiguities := handler.Ambiguities(src.handlers, src.routes, match)
+	if len(ambiguities) == 0 {
+		fmt.Fprintln(c.App.Writer, "No handler has an ambiguous or missing route.")
+		return nil
+	}
+
+	w := c.App.Writer
+	fmt.Fprintf(w, "%d handlers have an ambiguous or missing route.\n", len(ambiguities))
+	pinned := 0
+	for _, ambiguity := range ambiguities {
+		routes, ok, err := pickRoutes(w, reader, ambiguity)
+		if err != nil {
+			return err
+		}
+		if ok {
+			src.mapping.Pin(mapping.Key(ambiguity.Handler), routes)
+			pinned++
+		}
+	}
+	if pinned == 0 {
+		return nil
+	}
+
+	if err := src.mapping.Save(src.mappingPath); err != nil {
+		return err
+	}
+	fmt.Fprintf(w, "Saved the routes of %d handlers to %s.\n", pinned, src.mappingPath)
+	src.applyMapping()
+	return nil
+}
+
+// pickRoutes lists the candidates of ambiguity and reads the numbers of those
+// the handler serves, as in "1,3", or "n" for none. ok is false if the answer
+// is empty, leaving the handler to the matcher.
+func pickRoutes(w io.Writer, reader *bufio.Reader, ambiguity handler.Ambiguity) (routes []string, ok bool, err error) {
+	h := ambiguity.Handler
+	fmt.Fprintf(w, "\n%s (%s)\n", handlerLabel(h), h.Package)
+	if len(ambiguity.Candidates) == 0 {
+		fmt.Fprintln(w, "  no candidate route")
+	}
+	for i, candidate := range ambiguity.Candidates {
+		fmt.Fprintf(w, "  %d) %s  score %.2f: %s\n", i+1, mapping.FormatRoute(candidate.Route), candidate.Score, strings.Join(candidate.Reasons, ", "))
+	}
+
+	for {
+		fmt.Fprint(w, "Routes it serves (e.g. 1,2), n for none, or Enter to skip: ")
+		answer, err := reader.ReadString('\n')
+		if err != nil && err != io.EOF {
+			return nil, false, err
+		}
+		if err == io.EOF && answer == "" {
+			return nil, false, nil
+		}
+
+		answer = strings.TrimSpace(strings.ToLower(answer))
+		switch answer {
+		case "":
+			return nil, false, nil
+		case "n", "none":
+			return []string{}, true, nil
+		}
+
+		routes, err := selectedRoutes(answer, ambiguity.Candidates)
+		if err == nil {
+			return routes, true, nil
+		}
+		fmt.Fprintln(w, err)
+	}
+}
+
+// selectedRoutes returns the routes of the candidates numbered in answer, as in
+// "1,3" or "1 3".
+func selectedRoutes(answer string, candidates []matcher.Candidate) ([]string, error) {
+	var routes []string
+	fields := strings.FieldsFunc(answer, func(r rune) bool { return r == ',' || r == ' ' })
+	for _, field := range fields {
+		n, err := strconv.Atoi(field)
+		if err != nil || n < 1 || n > len(candidates) {
+			return nil, fmt.Errorf("invalid choice %q: expected a number from 1 to %d", field, len(candidates))
+		}
+		route := mapping.FormatRoute(candidates[n-1].Route)
+		if !slices.Contains(routes, route) {
+			routes = append(routes, route)
+		}
+	}
+	return routes, nil
+}
+
+// handlerLabel returns the name of h, qualified by its receiver type for a
+// method.
+func handlerLabel(h matcher.Handler) string {
+	if h.Receiver == "" {
+		return h.Name
+	}
+	return h.Receiver + "." + h.Name
+}
@@ -0,0 +1,88 @@
+package app
+
+import (
+	"bufio"
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/insectkorea/swagGPT/internal/handler"
+	"github.com/insectkorea/swagGPT/internal/matcher"
+	"github.com/insectkorea/swagGPT/internal/model"
+	"github.com/stretchr/testify/assert"
+)
+
+var testCandidates = []matcher.Candidate{
+	{Route: model.Route{Method: "GET", Pattern: "/users/:id"}, Score: 0.8, Reasons: []string{`path has "users"`}},
+	{Route: model.Route{Method: "DELETE", Pattern: "/users/:id"}, Score: 0.75},
+	{Route: model.Route{Method: "GET", Pattern: "/users"}, Score: 0.7},
+}
+
+func TestSelectedRoutes(t *testing.T) {
+	testCases := []struct {
+		answer   string
+		expected []string
+		err      string
+	}{
+		{answer: "1,3", expected: []string{"GET /users/{id}", "GET /users"}},
+		{answer: "1 3", expected: []string{"GET /users/{id}", "GET /users"}},
+		{answer: "2, 2", expected: []string{"DELETE /users/{id}"}},
+		{answer: "4", err: `invalid choice "4": expected a number from 1 to 3`},
+		{answer: "0", err: `invalid choice "0": expected a number from 1 to 3`},
+		{answer: "1,x", err: `invalid choice "x": expected a number from 1 to 3`},
+	}
+	for _, tc := range testCases {
+		routes, err := selectedRoutes(tc.answer, testCandidates)
+		if tc.err != "" {
+			assert.EqualError(t, err, tc.err, tc.answer)
+			continue
+		}
+		assert.NoError(t, err, tc.answer)
+		assert.Equal(t, tc.expected, routes, tc.answer)
+	}
+}
+
+func TestPickRoutes(t *testing.T) {
+	testCases := []struct {
+		name     string
+		input    string
+		expected []string
+		ok       bool
+		prompts  int
+	}{
+		{name: "Comma", input: "1,3\n", expected: []string{"GET /users/{id}", "GET /users"}, ok: true, prompts: 1},
+		{name: "Space", input: "1 3\n", expected: []string{"GET /users/{id}", "GET /users"}, ok: true, prompts: 1},
+		{name: "None", input: "n\n", expected: []string{}, ok: true, prompts: 1},
+		{name: "Blank", input: "\n", prompts: 1},
+		{name: "EOF", input: "", prompts: 1},
+		{name: "NoNewline", input: "2", expected: []string{"DELETE /users/{id}"}, ok: true, prompts: 1},
+		{name: "OutOfRange", input: "5\n2\n", expected: []string{"DELETE /users/{id}"}, ok: true, prompts: 2},
+		{name: "OutOfRangeThenBlank", input: "5\n\n", prompts: 2},
+	}
+	ambiguity := handler.Ambiguity{
+		Handler:    matcher.Handler{Name: "Get", Receiver: "UserHandler", Package: "example.com/app/api"},
+		Candidates: testCandidates,
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var out bytes.Buffer
+			routes, ok, err := pickRoutes(&out, bufio.NewReader(strings.NewReader(tc.input)), ambiguity)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.ok, ok)
+			assert.Equal(t, tc.expected, routes)
+			assert.Equal(t, tc.prompts, strings.Count(out.String(), "Routes it serves"))
+			assert.Contains(t, out.String(), "UserHandler.Get (example.com/app/api)\n  1) GET /users/{id}  score 0.80: path has \"users\"\n")
+		})
+	}
+}
+
+func TestPickRoutesNoCandidate(t *testing.T) {
+	var out bytes.Buffer
+	ambiguity := handler.Ambiguity{Handler: matcher.Handler{Name: "Health"}}
+	routes, ok, err := pickRoutes(&out, bufio.NewReader(strings.NewReader("1\nn\n")), ambiguity)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, []string{}, routes)
+	assert.Contains(t, out.String(), "  no candidate route\n")
+	assert.Contains(t, out.String(), `invalid choice "1": expected a number from 1 to 0`)
+}
@@ -0,0 +1,97 @@
+package app
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/insectkorea/swagGPT/internal/handler"
+	"github.com/urfave/cli/v2"
+)
+
+// routesCommand returns the command listing the route table, which needs no
+// API key.
+func routesCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "routes",
+		Usage: "List the routes found and the handlers they are bound to",
+		Action: func(c *cli.Context) error {
+			src, err := loadSources(c)
+			if err != nil {
+				return cli.Exit(err.Error(), 1)
+			}
+
+			entries := handler.RouteTable(src.files, src.routes, src.opts)
+			for i := range entries {
+				entries[i].Position = relativePosition(entries[i].Position)
+			}
+
+			if err := writeRouteTable(c.App.Writer, entries, c.String("format")); err != nil {
+				return cli.Exit(err.Error(), 1)
+			}
+			return nil
+		},
+		Flags: append(sourceFlags(),
+			&cli.StringFlag{
+				Name:  "format",
+				Usage: "Output format: table, json or csv",
+				Value: "table",
+			},
+		),
+	}
+}
+
+// writeRouteTable writes entries to w in format.
+func writeRouteTable(w io.Writer, entries []handler.RouteEntry, format string) error {
+	switch format {
+	case "table":
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, "METHOD\tPATTERN\tHANDLER\tPOSITION\tISSUE")
+		for _, entry := range entries {
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", entry.Method, entry.Pattern, entry.Handler, entry.Position, entry.Issue)
+		}
+		return tw.Flush()
+	case "json":
+		if entries == nil {
+			entries = []handler.RouteEntry{}
+		}
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(entries)
+	case "csv":
+		cw := csv.NewWriter(w)
+		if err := cw.Write([]string{"method", "pattern", "handler", "position", "issue"}); err != nil {
+			return err
+		}
+		for _, entry := range entries {
+			if err := cw.Write([]string{entry.Method, entry.Pattern, entry.Handler, entry.Position, entry.Issue}); err != nil {
+				return err
+			}
+		}
+		cw.Flush()
+		return cw.Error()
+	}
+	return fmt.Errorf("unknown format %q: expected table, json or csv", format)
+}
+
+// relativePosition returns pos relative to the working directory if it is in
+// it.
+func relativePosition(pos string) string {
+	if !filepath.IsAbs(pos) {
+		return pos
+	}
+	wd, err := os.Getwd()
+	if err != nil {
+		return pos
+	}
+	rel, err := filepath.Rel(wd, pos)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return pos
+	}
+	return rel
+}
@@ -0,0 +1,66 @@
+package app
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/insectkorea/swagGPT/internal/handler"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriteRouteTable(t *testing.T) {
+	entries := []handler.RouteEntry{
+		{Method: "GET", Pattern: "/users/:id", Handler: "UserHandler.Get", Position: "routes.go:10:2"},
+		{Handler: "Orphan", Position: "handlers.go:13:1", Issue: handler.IssueNoRoute},
+	}
+
+	testCases := []struct {
+		format   string
+		entries  []handler.RouteEntry
+		expected string
+	}{
+		{
+			format:  "table",
+			entries: entries,
+			expected: "METHOD  PATTERN     HANDLER          POSITION          ISSUE\n" +
+				"GET     /users/:id  UserHandler.Get  routes.go:10:2    \n" +
+				"                    Orphan           handlers.go:13:1  no route\n",
+		},
+		{
+			format:  "json",
+			entries: entries,
+			expected: `[
+  {
+    "method": "GET",
+    "pattern": "/users/:id",
+    "handler": "UserHandler.Get",
+    "position": "routes.go:10:2"
+  },
+  {
+    "method": "",
+    "pattern": "",
+    "handler": "Orphan",
+    "position": "handlers.go:13:1",
+    "issue": "no route"
+  }
+]
+`,
+		},
+		{format: "json", expected: "[]\n"},
+		{
+			format:  "csv",
+			entries: entries,
+			expected: `method,pattern,handler,position,issue
+GET,/users/:id,UserHandler.Get,routes.go:10:2,
+,,Orphan,handlers.go:13:1,no route
+`,
+		},
+	}
+	for _, tc := range testCases {
+		var buf bytes.Buffer
+		assert.NoError(t, writeRouteTable(&buf, tc.entries, tc.format), tc.format)
+		assert.Equal(t, tc.expected, buf.String(), tc.format)
+	}
+
+	assert.EqualError(t, writeRouteTable(&bytes.Buffer{}, entries, "xml"), `unknown format "xml": expected table, json or csv`)
+}
@@ -0,0 +1,75 @@
+package framework
+
+import (
+	"go/ast"
+	"go/types"
+)
+
+var chiImportPaths = []string{"github.com/go-chi/chi/v5", "github.com/go-chi/chi"}
+
+// chiFramework adapts chi, whose handlers are net/http handlers. Routes are
+// registered with r.Get(path, h), r.Method(method, path, h) or
+// r.Handle(pattern, h), nested with r.Route(prefix, func(r chi.Router) {...})
+// and r.Mount(prefix, sub), and r.Use(mw), r.With(mw) or r.Group(fn) add
+// middleware without changing the prefix.
+type chiFramework struct{}
+
+func (chiFramework) Name() string {
+	return "chi"
+}
+
+func (chiFramework) ImportPaths() []string {
+	return chiImportPaths
+}
+
+func (chiFramework) IsHandler(*ast.FuncType, *types.Info) bool {
+	return false
+}
+
+func (chiFramework) IsHandlerType(ast.Expr, *types.Info) bool {
+	return false
+}
+
+func (chiFramework) RouterCall(call *ast.CallExpr, info *types.Info) (Call, bool) {
+	if c, ok := verbRoute(call, info, titleVerbs); ok {
+		return c, true
+	}
+	if c, ok := patternRoute(call, info); ok {
+		return c, true
+	}
+	if c, ok := methodRoute(call, info, "Method", "MethodFunc"); ok && len(call.Args) == 3 {
+		return c, true
+	}
+
+	router, name, ok := methodCall(call)
+	if !ok {
+		return Call{}, false
+	}
+	switch name {
+	case "Route", "Mount":
+		if len(call.Args) != 2 {
+			return Call{}, false
+		}
+		path, unresolved, ok := pathArg(info, call.Args[0])
+		if !ok {
+			return Call{}, false
+		}
+		if name == "Route" {
+			return Call{Kind: Scope, Router: router, Path: path, Unresolved: unresolved}, true
+		}
+		return Call{Kind: Mount, Router: router, Path: path, Sub: call.Args[1], Unresolved: unresolved}, true
+	case "Group":
+		if len(call.Args) == 1 {
+			return Call{Kind: Scope, Router: router}, true
+		}
+	case "With":
+		return Call{Kind: Group, Router: router, Middleware: call.Args}, true
+	case "Use":
+		return useCall(call, info)
+	}
+	return Call{}, false
+}
+
+func (chiFramework) PromptHints() string {
+	return ""
+}
@@ -0,0 +1,65 @@
+package framework
+
+import (
+	"go/ast"
+	"go/types"
+)
+
+var echoImportPaths = []string{"github.com/labstack/echo/v4", "github.com/labstack/echo"}
+
+// echoFramework adapts Echo: func(c echo.Context) error handlers registered
+// with e.GET(path, h), e.Add(method, path, h), e.Match(methods, path, h) or
+// e.Any(path, h) on instances and e.Group(prefix) groups, guarded by the
+// middleware added with e.Use(mw) or passed after the handler.
+type echoFramework struct{}
+
+func (echoFramework) Name() string {
+	return "echo"
+}
+
+func (echoFramework) ImportPaths() []string {
+	return echoImportPaths
+}
+
+func (echoFramework) IsHandler(fnType *ast.FuncType, info *types.Info) bool {
+	params := FieldTypes(fnType.Params)
+	return len(params) == 1 && IsNamedType(info, params[0], "Context", echoImportPaths)
+}
+
+func (echoFramework) IsHandlerType(expr ast.Expr, info *types.Info) bool {
+	return IsNamedType(info, expr, "HandlerFunc", echoImportPaths)
+}
+
+func (echoFramework) RouterCall(call *ast.CallExpr, info *types.Info) (Call, bool) {
+	if c, ok := verbRoute(call, info, upperVerbs); ok {
+		// Echo takes route middleware after the handler
+		c.Routes[0].Handler, c.Routes[0].Middleware = call.Args[1], call.Args[2:]
+		return c, true
+	}
+	if c, ok := methodRoute(call, info, "Add", "Match"); ok {
+		for i := range c.Routes {
+			c.Routes[i].Handler, c.Routes[i].Middleware = call.Args[2], call.Args[3:]
+		}
+		return c, true
+	}
+	if c, ok := anyRoute(call, info, "Any"); ok {
+		for i := range c.Routes {
+			c.Routes[i].Handler, c.Routes[i].Middleware = call.Args[1], call.Args[2:]
+		}
+		return c, true
+	}
+	if c, ok := useCall(call, info); ok {
+		return c, true
+	}
+	return groupCall(call, info, "Group")
+}
+
+func (echoFramework) PromptHints() string {
+	return `This is an Echo handler. Read it as follows:
+- c.Param("name") reads the path parameter name.
+- c.QueryParam("name") reads the query parameter name.
+- c.Bind(&v) parses the request body into v.
+- c.JSON(code, v) responds with code and v; returning echo.NewHTTPError(code, ...) responds with an error.
+- echo.Map{...} is a map[string]any, documented as {object} map[string]any.
+`
+}
@@ -0,0 +1,84 @@
+package framework
+
+import (
+	"go/ast"
+	"go/types"
+	"slices"
+)
+
+var (
+	fiberImportPaths   = []string{"github.com/gofiber/fiber/v2", "github.com/gofiber/fiber"}
+	fiberV3ImportPaths = []string{"github.com/gofiber/fiber/v3"}
+)
+
+// titleVerbs maps the route registration methods of chi and Fiber to the HTTP
+// method they register. Connect and Trace are left out, since swag rejects
+// CONNECT and TRACE in @Router.
+var titleVerbs = map[string]string{
+	"Get":     "GET",
+	"Post":    "POST",
+	"Put":     "PUT",
+	"Delete":  "DELETE",
+	"Patch":   "PATCH",
+	"Options": "OPTIONS",
+	"Head":    "HEAD",
+}
+
+// fiberFramework adapts Fiber: func(c *fiber.Ctx) error handlers, or
+// func(c fiber.Ctx) error in v3, registered with app.Get(path, h),
+// app.Add(method, path, h) or app.All(path, h) on apps, app.Group(prefix)
+// groups and app.Route(prefix, func(r fiber.Router) {...}), guarded by the
+// middleware added with app.Use(mw) or passed before the handler.
+type fiberFramework struct{}
+
+func (fiberFramework) Name() string {
+	return "fiber"
+}
+
+func (fiberFramework) ImportPaths() []string {
+	return slices.Concat(fiberImportPaths, fiberV3ImportPaths)
+}
+
+func (fiberFramework) IsHandler(fnType *ast.FuncType, info *types.Info) bool {
+	params := FieldTypes(fnType.Params)
+	if len(params) != 1 {
+		return false
+	}
+	return IsPointerTo(info, params[0], "Ctx", fiberImportPaths) ||
+		IsNamedType(info, params[0], "Ctx", fiberV3ImportPaths)
+}
+
+func (f fiberFramework) IsHandlerType(expr ast.Expr, info *types.Info) bool {
+	return IsNamedType(info, expr, "Handler", f.ImportPaths())
+}
+
+func (fiberFramework) RouterCall(call *ast.CallExpr, info *types.Info) (Call, bool) {
+	if c, ok := verbRoute(call, info, titleVerbs); ok {
+		return c, true
+	}
+	if c, ok := methodRoute(call, info, "Add"); ok {
+		return c, true
+	}
+	if c, ok := anyRoute(call, info, "All"); ok {
+		return c, true
+	}
+	if c, ok := groupCall(call, info, "Group"); ok {
+		return c, true
+	}
+	if c, ok := groupCall(call, info, "Route"); ok && len(call.Args) >= 2 {
+		c.Kind, c.Middleware = Scope, nil
+		return c, true
+	}
+	return useCall(call, info)
+}
+
+func (fiberFramework) PromptHints() string {
+	return `This is a Fiber handler. Read it as follows:
+- c.Params("name") reads the path parameter name.
+- c.Query("name") reads the query parameter name.
+- c.BodyParser(&v) parses the request body into v, which is the body parameter.
+- c.Status(code).JSON(v) responds with code and v; c.JSON(v) alone responds with 200.
+- Returning fiber.NewError(code, ...) or an error such as fiber.ErrNotFound responds with that status code.
+- fiber.Map{...} is a map[string]any, documented as {object} map[string]any.
+`
+}
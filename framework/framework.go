@@ -0,0 +1,206 @@
+// Package framework describes the web frameworks swagGPT understands.
+//
+// Each framework is an adapter that tells the scanner which functions are
+// handlers, tells the route extractor which calls register routes or build
+// routers, and adds framework specific notes to the prompt. The built-in
+// adapters cover Gin, Echo, Fiber, chi, gorilla/mux and net/http; other
+// frameworks, such as in-house wrappers around them, can be added with
+// Register before running the tool.
+package framework
+
+import (
+	"go/ast"
+	"go/types"
+	"sync"
+)
+
+// MethodAny is the method of a route that accepts every HTTP method, such as a
+// net/http ServeMux pattern without a method.
+const MethodAny = "ANY"
+
+// Framework adapts swagGPT to a web framework.
+type Framework interface {
+	// Name returns the name of the framework, e.g. "gin".
+	Name() string
+	// ImportPaths returns the import paths of the framework's packages. Route
+	// extraction only consults the frameworks imported by the scanned file.
+	ImportPaths() []string
+	// IsHandler reports whether fnType is the signature of a handler.
+	IsHandler(fnType *ast.FuncType, info *types.Info) bool
+	// IsHandlerType reports whether expr denotes a named handler type of the
+	// framework, such as gin.HandlerFunc, so that functions returning one are
+	// recognized as handler factories.
+	IsHandlerType(expr ast.Expr, info *types.Info) bool
+	// RouterCall reports how call registers routes or builds a router, if it
+	// does. info resolves the constants paths may be built from.
+	RouterCall(call *ast.CallExpr, info *types.Info) (Call, bool)
+	// PromptHints returns notes on the framework's idioms that are added to
+	// the prompt for its handlers.
+	PromptHints() string
+}
+
+// CallKind classifies the calls a framework uses to build its routing tree.
+type CallKind int
+
+const (
+	// Registration registers Routes on Router.
+	Registration CallKind = iota
+	// Group returns a router whose routes are registered under Path on
+	// Router, as in Gin's r.Group("/v1") or gorilla/mux's
+	// r.PathPrefix("/api").Subrouter().
+	Group
+	// Scope registers the routes of the closure passed to the call under Path
+	// on Router, as in chi's r.Route("/users", func(r chi.Router) {...}).
+	Scope
+	// Mount mounts the router built by Sub under Path on Router, as in chi's
+	// r.Mount("/admin", adminRouter()).
+	Mount
+	// Use adds Middleware to Router, for the routes registered on it from then
+	// on, as in r.Use(auth.RequireJWT()).
+	Use
+)
+
+// Call is a call that registers routes or builds a router.
+type Call struct {
+	Kind CallKind
+	// Router is the expression of the router the call is made on.
+	Router ast.Expr
+	// Path is the path prefix of a Group, Scope or Mount call.
+	Path string
+	// Routes are the routes of a Registration call, relative to Router.
+	Routes []Route
+	// Sub is the mounted router of a Mount call.
+	Sub ast.Expr
+	// Chain lists the calls making up a registration built by a chain of
+	// calls, such as gorilla/mux's r.HandleFunc(path, h).Methods("GET").
+	Chain []*ast.CallExpr
+	// Unresolved is the path argument if its value cannot be determined
+	// statically, in which case Path, or the path of Routes, is empty.
+	Unresolved ast.Expr
+	// UnresolvedMethod is the method argument of a Registration call if its
+	// value cannot be determined statically, in which case Routes is empty.
+	UnresolvedMethod ast.Expr
+	// Middleware lists the middleware of a Use call, or the middleware a
+	// Group call adds to the group, as in r.Group("/admin", auth).
+	Middleware []ast.Expr
+}
+
+// Route is a route registered by a Registration call.
+type Route struct {
+	Method string
+	Host   string
+	Path   string
+	// Params lists the names of the path wildcards, e.g. "id" for "/items/{id}".
+	Params []string
+	// Handler is the handler argument of the registration.
+	Handler ast.Expr
+	// Middleware lists the middleware passed along with the handler, as in
+	// r.GET("/admin", auth, h).
+	Middleware []ast.Expr
+}
+
+var (
+	mu         sync.RWMutex
+	frameworks = builtins()
+)
+
+// builtins returns the frameworks supported out of the box.
+func builtins() []Framework {
+	return []Framework{
+		ginFramework{},
+		echoFramework{},
+		fiberFramework{},
+		chiFramework{},
+		gorillaFramework{},
+		httpFramework{},
+	}
+}
+
+// Register adds f to the known frameworks, replacing any framework of the
+// same name. Frameworks are consulted in the order they were registered,
+// after the built-in ones.
+func Register(f Framework) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	for i, known := range frameworks {
+		if known.Name() == f.Name() {
+			frameworks[i] = f
+			return
+		}
+	}
+	frameworks = append(frameworks, f)
+}
+
+// Reset forgets the registered frameworks and wrappers, leaving the built-in
+// frameworks only. Tests registering their own undo it with
+// t.Cleanup(framework.Reset).
+func Reset() {
+	mu.Lock()
+	defer mu.Unlock()
+
+	frameworks = builtins()
+	wrappers = map[string]bool{}
+}
+
+// All returns the known frameworks.
+func All() []Framework {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	return append([]Framework(nil), frameworks...)
+}
+
+// Lookup returns the framework with the given name.
+func Lookup(name string) (Framework, bool) {
+	for _, f := range All() {
+		if f.Name() == name {
+			return f, true
+		}
+	}
+	return nil, false
+}
+
+// Imported returns the frameworks imported by file, or all known frameworks
+// if file imports none of them, as when routes are registered through a
+// wrapper package. net/http is imported by most files for its status codes
+// alone, so importing only net/http does not narrow the frameworks down.
+func Imported(file *ast.File) []Framework {
+	all := All()
+	var imported []Framework
+	for _, f := range all {
+		if importsAny(file, f.ImportPaths()) {
+			imported = append(imported, f)
+		}
+	}
+	if len(imported) == 0 || (len(imported) == 1 && imported[0].Name() == httpFramework{}.Name()) {
+		return all
+	}
+	return imported
+}
+
+// HandlerFramework returns the framework fnType is a handler signature of,
+// directly or as a factory returning a handler, or nil if fnType is not a
+// handler.
+func HandlerFramework(fnType *ast.FuncType, info *types.Info) Framework {
+	all := All()
+	for _, f := range all {
+		if f.IsHandler(fnType, info) {
+			return f
+		}
+	}
+
+	if fnType.Results.NumFields() != 1 {
+		return nil
+	}
+	resultType := fnType.Results.List[0].Type
+	for _, f := range all {
+		if funcType, ok := resultType.(*ast.FuncType); ok && f.IsHandler(funcType, info) {
+			return f
+		}
+		if f.IsHandlerType(resultType, info) {
+			return f
+		}
+	}
+	return nil
+}
@@ -0,0 +1,254 @@
+package framework
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// wrapperFramework is a router wrapper registering routes with
+// r.Route(method, path, h) and handlers taking a *web.Context.
+type wrapperFramework struct{}
+
+var wrapperImportPaths = []string{"example.com/web"}
+
+func (wrapperFramework) Name() string          { return "web" }
+func (wrapperFramework) ImportPaths() []string { return wrapperImportPaths }
+func (wrapperFramework) PromptHints() string   { return "" }
+
+func (wrapperFramework) IsHandler(fnType *ast.FuncType, info *types.Info) bool {
+	params := FieldTypes(fnType.Params)
+	return len(params) == 1 && IsPointerTo(info, params[0], "Context", wrapperImportPaths)
+}
+
+func (wrapperFramework) IsHandlerType(ast.Expr, *types.Info) bool {
+	return false
+}
+
+func (wrapperFramework) RouterCall(call *ast.CallExpr, info *types.Info) (Call, bool) {
+	router, name, ok := methodCall(call)
+	if !ok || name != "Route" || len(call.Args) != 3 {
+		return Call{}, false
+	}
+	method, ok := MethodLiteral(info, call.Args[0])
+	if !ok {
+		return Call{}, false
+	}
+	path, ok := StringValue(info, call.Args[1])
+	if !ok {
+		return Call{}, false
+	}
+	return Call{Kind: Registration, Router: router, Routes: []Route{{Method: method, Path: path}}}, true
+}
+
+func registerForTest(t *testing.T, f Framework) {
+	t.Helper()
+	t.Cleanup(Reset)
+	Register(f)
+}
+
+func parseSource(t *testing.T, src string) (*ast.File, *types.Info) {
+	t.Helper()
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "main.go", src, 0)
+	assert.NoError(t, err)
+
+	info := &types.Info{
+		Types:     map[ast.Expr]types.TypeAndValue{},
+		Defs:      map[*ast.Ident]types.Object{},
+		Uses:      map[*ast.Ident]types.Object{},
+		Implicits: map[ast.Node]types.Object{},
+	}
+	conf := types.Config{Error: func(error) {}}
+	_, _ = conf.Check(file.Name.Name, fset, []*ast.File{file}, info)
+	return file, info
+}
+
+func TestRegister(t *testing.T) {
+	registerForTest(t, wrapperFramework{})
+
+	f, ok := Lookup("web")
+	assert.True(t, ok)
+	assert.Equal(t, "web", f.Name())
+	assert.Equal(t, "web", All()[len(All())-1].Name())
+
+	// Registering a framework under a built-in name replaces it
+	count := len(All())
+	Register(namedFramework{wrapperFramework{}, "gin"})
+	assert.Len(t, All(), count)
+	f, _ = Lookup("gin")
+	assert.IsType(t, namedFramework{}, f)
+}
+
+type namedFramework struct {
+	wrapperFramework
+	name string
+}
+
+func (f namedFramework) Name() string { return f.name }
+
+func TestImported(t *testing.T) {
+	registerForTest(t, wrapperFramework{})
+
+	testCases := []struct {
+		name     string
+		src      string
+		expected []string
+	}{
+		{
+			name:     "framework import",
+			src:      "package main\n\nimport (\n\t\"net/http\"\n\n\t\"github.com/go-chi/chi/v5\"\n)\n",
+			expected: []string{"chi", "net/http"},
+		},
+		{
+			name:     "registered framework import",
+			src:      "package main\n\nimport \"example.com/web\"\n",
+			expected: []string{"web"},
+		},
+		{
+			name:     "net/http only",
+			src:      "package main\n\nimport \"net/http\"\n",
+			expected: names(All()),
+		},
+		{
+			name:     "no framework import",
+			src:      "package main\n\nimport \"example.com/router\"\n",
+			expected: names(All()),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			file, _ := parseSource(t, tc.src)
+			assert.Equal(t, tc.expected, names(Imported(file)))
+		})
+	}
+}
+
+func TestHandlerFramework(t *testing.T) {
+	registerForTest(t, wrapperFramework{})
+
+	file, info := parseSource(t, `package main
+
+import (
+	"net/http"
+
+	"example.com/web"
+	"github.com/gin-gonic/gin"
+)
+
+func GetUser(c *web.Context) {}
+
+func ListUsers(c *gin.Context) {}
+
+func ItemsHandler() http.HandlerFunc { return nil }
+
+func NotAHandler(id string) {}
+`)
+
+	var got []string
+	for _, decl := range file.Decls {
+		if fn, ok := decl.(*ast.FuncDecl); ok {
+			if f := HandlerFramework(fn.Type, info); f != nil {
+				got = append(got, fn.Name.Name+":"+f.Name())
+			}
+		}
+	}
+	assert.Equal(t, []string{"GetUser:web", "ListUsers:gin", "ItemsHandler:net/http"}, got)
+}
+
+func names(frameworks []Framework) []string {
+	var names []string
+	for _, f := range frameworks {
+		names = append(names, f.Name())
+	}
+	return names
+}
+
+func TestSignature(t *testing.T) {
+	file, info := parseSource(t, `package main
+
+import (
+	"example.com/app/appctx"
+	"github.com/gin-gonic/gin"
+)
+
+func GetOrder(ctx *appctx.Context) (any, error) { return nil, nil }
+
+func ListOrders(ctx *appctx.Context) (interface{}, error) { return nil, nil }
+
+func Index(c *gin.Context) error { return nil }
+
+func Wrap(h func(*appctx.Context) (any, error)) {}
+`)
+
+	appctx := NewSignature("appctx", []string{"*example.com/app/appctx.Context"}, []string{"any", "error"}, "")
+	ginError := NewSignature("gin-error", []string{"*github.com/gin-gonic/gin.Context"}, []string{"error"}, "")
+
+	var got []string
+	for _, decl := range file.Decls {
+		if fn, ok := decl.(*ast.FuncDecl); ok {
+			for _, f := range []Framework{appctx, ginError} {
+				if f.IsHandler(fn.Type, info) {
+					got = append(got, fn.Name.Name+":"+f.Name())
+				}
+			}
+		}
+	}
+	assert.Equal(t, []string{"GetOrder:appctx", "ListOrders:appctx", "Index:gin-error"}, got)
+}
+
+func TestUnwrap(t *testing.T) {
+	t.Cleanup(Reset)
+	RegisterWrapper("example.com/app/api.Wrap")
+	RegisterWrapper("adapt")
+
+	file, _ := parseSource(t, `package main
+
+import (
+	"net/http"
+
+	web "example.com/app/api"
+	"example.com/other/api"
+	"github.com/justinas/alice"
+)
+
+var routes = []any{
+	web.Wrap(h.List),
+	adapt(web.Wrap(GetOrder)),
+	api.Wrap(CreateOrder),
+	Index,
+	http.StripPrefix("/static", (http.HandlerFunc(files.Serve))),
+	alice.New(auth, logging).Then(http.HandlerFunc(DeleteOrder)),
+	ListOrders(store),
+}
+`)
+
+	var got []string
+	ast.Inspect(file, func(node ast.Node) bool {
+		if lit, ok := node.(*ast.CompositeLit); ok {
+			for _, elt := range lit.Elts {
+				got = append(got, types.ExprString(Unwrap(file, elt)))
+			}
+			return false
+		}
+		return true
+	})
+	assert.Equal(t, []string{"h.List", "GetOrder", "api.Wrap(CreateOrder)", "Index", "files.Serve", "DeleteOrder", "ListOrders(store)"}, got)
+}
+
+func TestReset(t *testing.T) {
+	builtin := All()
+	Register(namedFramework{wrapperFramework{}, "gin"})
+	Register(wrapperFramework{})
+	RegisterWrapper("adapt")
+
+	Reset()
+	assert.Equal(t, builtin, All())
+	fn := &ast.FuncDecl{Name: ast.NewIdent("adapt"), Type: &ast.FuncType{}}
+	assert.False(t, IsWrapper(fn, &types.Info{}))
+}
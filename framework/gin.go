@@ -0,0 +1,69 @@
+package framework
+
+import (
+	"go/ast"
+	"go/types"
+)
+
+var ginImportPaths = []string{"github.com/gin-gonic/gin"}
+
+// upperVerbs maps the route registration methods of Gin and Echo to the HTTP
+// method they register.
+var upperVerbs = map[string]string{
+	"GET":     "GET",
+	"POST":    "POST",
+	"PUT":     "PUT",
+	"DELETE":  "DELETE",
+	"PATCH":   "PATCH",
+	"OPTIONS": "OPTIONS",
+	"HEAD":    "HEAD",
+}
+
+// ginFramework adapts Gin: func(c *gin.Context) handlers registered with
+// r.GET(path, h), r.Handle(method, path, h), r.Match(methods, path, h) or
+// r.Any(path, h) on engines and r.Group(prefix) groups, guarded by the
+// middleware added with r.Use(mw) or passed before the handler.
+type ginFramework struct{}
+
+func (ginFramework) Name() string {
+	return "gin"
+}
+
+func (ginFramework) ImportPaths() []string {
+	return ginImportPaths
+}
+
+func (ginFramework) IsHandler(fnType *ast.FuncType, info *types.Info) bool {
+	params := FieldTypes(fnType.Params)
+	return len(params) == 1 && IsPointerTo(info, params[0], "Context", ginImportPaths)
+}
+
+func (ginFramework) IsHandlerType(expr ast.Expr, info *types.Info) bool {
+	return IsNamedType(info, expr, "HandlerFunc", ginImportPaths)
+}
+
+func (ginFramework) RouterCall(call *ast.CallExpr, info *types.Info) (Call, bool) {
+	if c, ok := verbRoute(call, info, upperVerbs); ok {
+		return c, true
+	}
+	if c, ok := methodRoute(call, info, "Handle", "Match"); ok {
+		return c, true
+	}
+	if c, ok := anyRoute(call, info, "Any"); ok {
+		return c, true
+	}
+	if c, ok := useCall(call, info); ok {
+		return c, true
+	}
+	return groupCall(call, info, "Group")
+}
+
+func (ginFramework) PromptHints() string {
+	return `This is a Gin handler. Read it as follows:
+- c.Param("name") reads the path parameter name.
+- c.Query("name") and c.DefaultQuery("name", ...) read the query parameter name.
+- c.ShouldBindJSON(&v), c.BindJSON(&v) and c.ShouldBind(&v) parse the request body into v.
+- c.JSON(code, v) responds with code and v; c.AbortWithStatusJSON(code, v) responds with an error.
+- gin.H{...} is a map[string]any, documented as {object} map[string]any.
+`
+}
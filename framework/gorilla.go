@@ -0,0 +1,174 @@
+package framework
+
+import (
+	"go/ast"
+	"go/types"
+)
+
+var gorillaImportPaths = []string{"github.com/gorilla/mux"}
+
+// gorillaFramework adapts gorilla/mux, whose handlers are net/http handlers.
+// Routes are built by chains of calls in which the methods come after the
+// path, r.PathPrefix(prefix).Subrouter() creates a prefixed router and
+// r.Use(mw) adds middleware:
+//
+//	r.HandleFunc("/users/{id}", h).Methods("GET")
+//	r.Path("/users").Methods(http.MethodPost).HandlerFunc(h)
+type gorillaFramework struct{}
+
+func (gorillaFramework) Name() string {
+	return "gorilla/mux"
+}
+
+func (gorillaFramework) ImportPaths() []string {
+	return gorillaImportPaths
+}
+
+func (gorillaFramework) IsHandler(*ast.FuncType, *types.Info) bool {
+	return false
+}
+
+func (gorillaFramework) IsHandlerType(ast.Expr, *types.Info) bool {
+	return false
+}
+
+func (gorillaFramework) RouterCall(call *ast.CallExpr, info *types.Info) (Call, bool) {
+	if c, ok := routeChain(call, info); ok {
+		return c, true
+	}
+	if c, ok := subrouter(call, info); ok {
+		return c, true
+	}
+	if c, ok := useCall(call, info); ok {
+		return c, true
+	}
+	return patternRoute(call, info)
+}
+
+func (gorillaFramework) PromptHints() string {
+	return ""
+}
+
+// routeChainCalls lists the methods of gorilla/mux's Router and Route that can
+// appear in a route chain. Those not handled by routeChain only add matchers
+// that do not change the route.
+var routeChainCalls = map[string]bool{
+	"HandleFunc":    true,
+	"Handle":        true,
+	"HandlerFunc":   true,
+	"Handler":       true,
+	"Path":          true,
+	"PathPrefix":    true,
+	"Methods":       true,
+	"Name":          true,
+	"Host":          true,
+	"Schemes":       true,
+	"Headers":       true,
+	"Queries":       true,
+	"MatcherFunc":   true,
+	"BuildVarsFunc": true,
+}
+
+// routeChain parses the chain of calls ending in call, registering one route
+// per method. Single calls are not chains; they are handled as plain
+// registrations.
+func routeChain(call *ast.CallExpr, info *types.Info) (Call, bool) {
+	var (
+		path       string
+		unresolved ast.Expr
+		methods    []string
+		handler    ast.Expr
+		chain      []*ast.CallExpr
+	)
+
+	var expr ast.Expr = call
+	for {
+		x, ok := expr.(*ast.CallExpr)
+		if !ok {
+			break
+		}
+		router, name, ok := methodCall(x)
+		if !ok || !routeChainCalls[name] {
+			break
+		}
+
+		switch name {
+		case "HandleFunc", "Handle":
+			if len(x.Args) != 2 {
+				return Call{}, false
+			}
+			handler = x.Args[1]
+			fallthrough
+		case "Path", "PathPrefix":
+			if len(x.Args) == 0 {
+				return Call{}, false
+			}
+			p, u, ok := pathArg(info, x.Args[0])
+			if !ok {
+				return Call{}, false
+			}
+			path, unresolved = p, u
+		case "HandlerFunc", "Handler":
+			if len(x.Args) == 1 {
+				handler = x.Args[0]
+			}
+		case "Methods":
+			for _, arg := range x.Args {
+				method, ok := MethodLiteral(info, arg)
+				if !ok {
+					return Call{}, false
+				}
+				methods = append(methods, method)
+			}
+		}
+
+		chain = append(chain, x)
+		expr = router
+	}
+
+	if len(chain) < 2 || handler == nil {
+		return Call{}, false
+	}
+
+	if len(methods) == 0 {
+		methods = []string{MethodAny}
+	}
+	path, params := NormalizeWildcards(path)
+	c := Call{Kind: Registration, Router: expr, Chain: chain, Unresolved: unresolved}
+	for _, method := range methods {
+		c.Routes = append(c.Routes, Route{Method: method, Path: path, Params: params, Handler: handler})
+	}
+	return c, true
+}
+
+// subrouter parses a call creating a Subrouter from the route it is called on,
+// as in r.PathPrefix("/api").Subrouter().
+func subrouter(call *ast.CallExpr, info *types.Info) (Call, bool) {
+	route, name, ok := methodCall(call)
+	if !ok || name != "Subrouter" {
+		return Call{}, false
+	}
+
+	var (
+		path       string
+		unresolved ast.Expr
+	)
+	expr := route
+	for {
+		x, ok := expr.(*ast.CallExpr)
+		if !ok {
+			break
+		}
+		router, name, ok := methodCall(x)
+		if !ok || !routeChainCalls[name] {
+			break
+		}
+		if (name == "PathPrefix" || name == "Path") && len(x.Args) == 1 {
+			if p, u, ok := pathArg(info, x.Args[0]); ok {
+				path, unresolved = p, u
+			}
+		}
+		expr = router
+	}
+	return Call{Kind: Group, Router: expr, Path: path, Unresolved: unresolved}, true
+}
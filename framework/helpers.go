@@ -0,0 +1,520 @@
+package framework
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// IsNamedType reports whether expr denotes the type called name declared in a
+// package imported from one of importPaths, whatever the local import alias.
+func IsNamedType(info *types.Info, expr ast.Expr, name string, importPaths []string) bool {
+	if t := info.TypeOf(expr); t != nil {
+		if named, ok := types.Unalias(t).(*types.Named); ok {
+			obj := named.Obj()
+			return obj.Name() == name && obj.Pkg() != nil && slices.Contains(importPaths, obj.Pkg().Path())
+		}
+	}
+
+	// The type is invalid when the imported package could not be loaded, but
+	// the qualifier still resolves to the import it refers to.
+	selectorExpr, ok := expr.(*ast.SelectorExpr)
+	if !ok || selectorExpr.Sel.Name != name {
+		return false
+	}
+	ident, ok := selectorExpr.X.(*ast.Ident)
+	if !ok {
+		return false
+	}
+	if obj := info.Uses[ident]; obj != nil {
+		pkgName, ok := obj.(*types.PkgName)
+		return ok && slices.Contains(importPaths, pkgName.Imported().Path())
+	}
+	return isUnresolvedImport(info, ident.Name, importPaths)
+}
+
+// IsPointerTo reports whether expr is a pointer to the type called name
+// declared in a package imported from one of importPaths.
+func IsPointerTo(info *types.Info, expr ast.Expr, name string, importPaths []string) bool {
+	starExpr, ok := expr.(*ast.StarExpr)
+	return ok && IsNamedType(info, starExpr.X, name, importPaths)
+}
+
+// isUnresolvedImport reports whether name is the package name of an unnamed
+// import of one of importPaths that could not be loaded.
+func isUnresolvedImport(info *types.Info, name string, importPaths []string) bool {
+	path, ok := unresolvedImportPath(info, name)
+	return ok && slices.Contains(importPaths, path)
+}
+
+// unresolvedImportPath returns the path of the unnamed import that could not
+// be loaded whose package is conventionally called name. The type checker
+// names such packages after the last element of their path, which is wrong
+// for paths such as github.com/gofiber/fiber/v2, leaving the qualifier
+// undefined.
+func unresolvedImportPath(info *types.Info, name string) (string, bool) {
+	for node := range info.Implicits {
+		spec, ok := node.(*ast.ImportSpec)
+		if !ok {
+			continue
+		}
+		path, err := strconv.Unquote(spec.Path.Value)
+		if err == nil && defaultPackageName(path) == name {
+			return path, true
+		}
+	}
+	return "", false
+}
+
+// TypeString returns the type expr denotes with packages qualified by their
+// import path, as in "*github.com/gin-gonic/gin.Context", or an empty string
+// if it cannot be determined. The empty interface is written "any".
+func TypeString(info *types.Info, expr ast.Expr) string {
+	if t := info.TypeOf(expr); t != nil && t != types.Typ[types.Invalid] {
+		if s := types.TypeString(t, nil); !strings.Contains(s, "invalid type") {
+			return normalizeAny(s)
+		}
+	}
+
+	// The imported package could not be loaded, but the qualifier still
+	// resolves to the import it refers to.
+	switch x := expr.(type) {
+	case *ast.Ident:
+		return normalizeAny(x.Name)
+	case *ast.StarExpr:
+		if s := TypeString(info, x.X); s != "" {
+			return "*" + s
+		}
+	case *ast.ArrayType:
+		if s := TypeString(info, x.Elt); s != "" && x.Len == nil {
+			return "[]" + s
+		}
+	case *ast.SelectorExpr:
+		ident, ok := x.X.(*ast.Ident)
+		if !ok {
+			break
+		}
+		if pkgName, ok := info.Uses[ident].(*types.PkgName); ok {
+			return pkgName.Imported().Path() + "." + x.Sel.Name
+		}
+		if path, ok := unresolvedImportPath(info, ident.Name); ok {
+			return path + "." + x.Sel.Name
+		}
+	}
+	return ""
+}
+
+func normalizeAny(s string) string {
+	if s == "interface{}" {
+		return "any"
+	}
+	return s
+}
+
+// defaultPackageName returns the package name conventionally declared by the
+// package imported from path, ignoring a major version suffix.
+func defaultPackageName(path string) string {
+	elems := strings.Split(path, "/")
+	name := elems[len(elems)-1]
+	if len(elems) > 1 && len(name) > 1 && name[0] == 'v' && strings.Trim(name[1:], "0123456789") == "" {
+		name = elems[len(elems)-2]
+	}
+	return name
+}
+
+// importsAny reports whether file imports one of importPaths.
+func importsAny(file *ast.File, importPaths []string) bool {
+	for _, spec := range file.Imports {
+		if path, err := strconv.Unquote(spec.Path.Value); err == nil && slices.Contains(importPaths, path) {
+			return true
+		}
+	}
+	return false
+}
+
+// FieldTypes returns the type of every field in fields, repeating the type of
+// grouped fields such as `a, b string`.
+func FieldTypes(fields *ast.FieldList) []ast.Expr {
+	if fields == nil {
+		return nil
+	}
+	var exprs []ast.Expr
+	for _, field := range fields.List {
+		for i := 0; i < max(len(field.Names), 1); i++ {
+			exprs = append(exprs, field.Type)
+		}
+	}
+	return exprs
+}
+
+// StringLiteral returns the value of expr if it is a string literal.
+func StringLiteral(expr ast.Expr) (string, bool) {
+	lit, ok := expr.(*ast.BasicLit)
+	if !ok || lit.Kind != token.STRING {
+		return "", false
+	}
+	value, err := strconv.Unquote(lit.Value)
+	if err != nil {
+		return "", false
+	}
+	return value, true
+}
+
+// StringValue returns the value of expr if it can be determined statically: a
+// string literal, a constant, a concatenation of those or a call of path.Join
+// on them.
+func StringValue(info *types.Info, expr ast.Expr) (string, bool) {
+	if value, ok := StringLiteral(expr); ok {
+		return value, true
+	}
+	if info != nil {
+		if tv, ok := info.Types[expr]; ok && tv.Value != nil && tv.Value.Kind() == constant.String {
+			return constant.StringVal(tv.Value), true
+		}
+	}
+
+	switch x := expr.(type) {
+	case *ast.ParenExpr:
+		return StringValue(info, x.X)
+	case *ast.BinaryExpr:
+		if x.Op != token.ADD {
+			return "", false
+		}
+		left, ok := StringValue(info, x.X)
+		if !ok {
+			return "", false
+		}
+		right, ok := StringValue(info, x.Y)
+		return left + right, ok
+	case *ast.CallExpr:
+		if !isPathJoin(info, x) {
+			return "", false
+		}
+		var elems []string
+		for _, arg := range x.Args {
+			elem, ok := StringValue(info, arg)
+			if !ok {
+				return "", false
+			}
+			elems = append(elems, elem)
+		}
+		return path.Join(elems...), true
+	}
+	return "", false
+}
+
+// isPathJoin reports whether call is a call of path.Join.
+func isPathJoin(info *types.Info, call *ast.CallExpr) bool {
+	selExpr, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || selExpr.Sel.Name != "Join" || call.Ellipsis.IsValid() || info == nil {
+		return false
+	}
+	ident, ok := selExpr.X.(*ast.Ident)
+	if !ok {
+		return false
+	}
+	pkgName, ok := info.Uses[ident].(*types.PkgName)
+	return ok && pkgName.Imported().Path() == "path"
+}
+
+// isString reports whether expr is of a string type.
+func isString(info *types.Info, expr ast.Expr) bool {
+	if info == nil {
+		return false
+	}
+	t := info.TypeOf(expr)
+	if t == nil {
+		return false
+	}
+	basic, ok := t.Underlying().(*types.Basic)
+	return ok && basic.Info()&types.IsString != 0
+}
+
+// pathArg returns the value of the path argument expr. A string expression
+// whose value cannot be determined statically is returned as unresolved; any
+// other expression is not a path.
+func pathArg(info *types.Info, expr ast.Expr) (path string, unresolved ast.Expr, ok bool) {
+	if path, ok := StringValue(info, expr); ok {
+		return path, nil, true
+	}
+	if isString(info, expr) {
+		return "", expr, true
+	}
+	return "", nil, false
+}
+
+// MethodLiteral returns the HTTP method expr denotes, either a string literal
+// or one of the net/http Method constants such as http.MethodGet.
+func MethodLiteral(info *types.Info, expr ast.Expr) (string, bool) {
+	if method, ok := StringLiteral(expr); ok {
+		return strings.ToUpper(method), true
+	}
+	if selExpr, ok := expr.(*ast.SelectorExpr); ok && strings.HasPrefix(selExpr.Sel.Name, "Method") && isPackage(info, selExpr.X, "net/http") {
+		if method := strings.TrimPrefix(selExpr.Sel.Name, "Method"); method != "" {
+			return strings.ToUpper(method), true
+		}
+	}
+	return "", false
+}
+
+// isPackage reports whether expr is the name of the package imported from
+// importPath. Without type information, the package is expected to go by its
+// default name.
+func isPackage(info *types.Info, expr ast.Expr, importPath string) bool {
+	ident, ok := expr.(*ast.Ident)
+	if !ok {
+		return false
+	}
+	if info == nil {
+		return ident.Name == defaultPackageName(importPath)
+	}
+	if obj := info.Uses[ident]; obj != nil {
+		pkgName, ok := obj.(*types.PkgName)
+		return ok && pkgName.Imported().Path() == importPath
+	}
+	return isUnresolvedImport(info, ident.Name, []string{importPath})
+}
+
+// ParseServeMuxPattern parses a net/http ServeMux pattern of the form
+// "[METHOD ][HOST]/[PATH]".
+func ParseServeMuxPattern(pattern string) (Route, bool) {
+	route := Route{Method: MethodAny}
+
+	var rest string
+	switch fields := strings.Fields(pattern); len(fields) {
+	case 1:
+		rest = fields[0]
+	case 2:
+		route.Method, rest = fields[0], fields[1]
+	default:
+		return Route{}, false
+	}
+
+	slash := strings.Index(rest, "/")
+	if slash < 0 {
+		return Route{}, false
+	}
+	route.Host = rest[:slash]
+	route.Path, route.Params = NormalizeWildcards(rest[slash:])
+
+	return route, true
+}
+
+// NormalizeWildcards rewrites the {name} and {name...} wildcards of ServeMux
+// and chi as well as the {name:regexp} variables of gorilla/mux to {name}, and
+// returns their names. The ServeMux {$} end anchor is dropped.
+func NormalizeWildcards(path string) (string, []string) {
+	var b strings.Builder
+	var params []string
+	for i := 0; i < len(path); i++ {
+		if path[i] != '{' {
+			b.WriteByte(path[i])
+			continue
+		}
+
+		// Find the matching brace; gorilla/mux patterns may contain braces,
+		// as in {id:[0-9]{4}}
+		end, depth := -1, 0
+		for j := i; j < len(path) && end < 0; j++ {
+			switch path[j] {
+			case '{':
+				depth++
+			case '}':
+				depth--
+				if depth == 0 {
+					end = j
+				}
+			}
+		}
+		if end < 0 {
+			b.WriteString(path[i:])
+			break
+		}
+
+		name, _, _ := strings.Cut(path[i+1:end], ":")
+		name = strings.TrimSuffix(name, "...")
+		i = end
+		if name == "$" {
+			continue
+		}
+		params = append(params, name)
+		b.WriteString("{" + name + "}")
+	}
+	return b.String(), params
+}
+
+// methodCall returns the receiver and method name of a call such as r.GET(...).
+func methodCall(call *ast.CallExpr) (ast.Expr, string, bool) {
+	selExpr, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return nil, "", false
+	}
+	return selExpr.X, selExpr.Sel.Name, true
+}
+
+// verbRoute parses a registration named after the HTTP method it registers,
+// such as r.GET("/users", h), using verbs to map method names to methods. The
+// handler is the last argument, following any middleware.
+func verbRoute(call *ast.CallExpr, info *types.Info, verbs map[string]string) (Call, bool) {
+	router, name, ok := methodCall(call)
+	if !ok || verbs[name] == "" || len(call.Args) < 2 {
+		return Call{}, false
+	}
+	path, unresolved, ok := pathArg(info, call.Args[0])
+	if !ok {
+		return Call{}, false
+	}
+	// A handler is never a string, unlike the default value of fiber's
+	// c.Get("X-Request-ID", "none") in handler bodies
+	handler := call.Args[len(call.Args)-1]
+	if _, ok := handler.(*ast.BasicLit); ok || isString(info, handler) {
+		return Call{}, false
+	}
+	route := Route{Method: verbs[name], Path: path, Handler: handler, Middleware: call.Args[1 : len(call.Args)-1]}
+	return Call{Kind: Registration, Router: router, Routes: []Route{route}, Unresolved: unresolved}, true
+}
+
+// methodRoute parses a registration taking the HTTP method, or a slice
+// literal of methods, before the path, such as Gin's r.Handle("GET", path, h)
+// or Echo's e.Match([]string{"GET", "HEAD"}, path, h), made with one of the
+// methods names. One route is registered per method. The handler is the last
+// argument, following any middleware.
+func methodRoute(call *ast.CallExpr, info *types.Info, names ...string) (Call, bool) {
+	router, name, ok := methodCall(call)
+	if !ok || !slices.Contains(names, name) || len(call.Args) < 3 {
+		return Call{}, false
+	}
+	methods, unresolvedMethod, ok := methodArg(info, call.Args[0])
+	if !ok {
+		return Call{}, false
+	}
+	path, unresolved, ok := pathArg(info, call.Args[1])
+	if !ok {
+		return Call{}, false
+	}
+	routes := methodRoutes(methods, path, call.Args[len(call.Args)-1], call.Args[2:len(call.Args)-1])
+	return Call{Kind: Registration, Router: router, Routes: routes, Unresolved: unresolved, UnresolvedMethod: unresolvedMethod}, true
+}
+
+// anyMethods are the methods a route registered for any method, as with Gin's
+// r.Any(path, h), is documented with. CONNECT and TRACE are left out, since
+// swag rejects them in @Router.
+var anyMethods = []string{"GET", "POST", "PUT", "PATCH", "DELETE", "HEAD", "OPTIONS"}
+
+// anyRoute parses a registration for every method, such as Gin's
+// r.Any(path, h), made with the method name. One route is registered per
+// method of anyMethods. The handler is the last argument, following any
+// middleware.
+func anyRoute(call *ast.CallExpr, info *types.Info, name string) (Call, bool) {
+	router, method, ok := methodCall(call)
+	if !ok || method != name || len(call.Args) < 2 {
+		return Call{}, false
+	}
+	path, unresolved, ok := pathArg(info, call.Args[0])
+	if !ok {
+		return Call{}, false
+	}
+	routes := methodRoutes(anyMethods, path, call.Args[len(call.Args)-1], call.Args[1:len(call.Args)-1])
+	return Call{Kind: Registration, Router: router, Routes: routes, Unresolved: unresolved}, true
+}
+
+// methodArg returns the HTTP methods of the method argument expr, a method or
+// a slice of methods. A string or string slice expression whose value cannot
+// be determined statically is returned as unresolved; any other expression is
+// not a method.
+func methodArg(info *types.Info, expr ast.Expr) (methods []string, unresolved ast.Expr, ok bool) {
+	if methods, ok := methodList(info, expr); ok {
+		return methods, nil, true
+	}
+	if method, ok := StringValue(info, expr); ok {
+		return []string{strings.ToUpper(method)}, nil, true
+	}
+	if isString(info, expr) || isStringSlice(info, expr) {
+		return nil, expr, true
+	}
+	return nil, nil, false
+}
+
+// isStringSlice reports whether expr is a slice of strings according to info.
+func isStringSlice(info *types.Info, expr ast.Expr) bool {
+	if info == nil {
+		return false
+	}
+	t := info.TypeOf(expr)
+	if t == nil {
+		return false
+	}
+	slice, ok := t.Underlying().(*types.Slice)
+	if !ok {
+		return false
+	}
+	basic, ok := slice.Elem().Underlying().(*types.Basic)
+	return ok && basic.Info()&types.IsString != 0
+}
+
+// methodList returns the HTTP methods expr denotes, either a single method or
+// a slice literal of methods.
+func methodList(info *types.Info, expr ast.Expr) ([]string, bool) {
+	if method, ok := MethodLiteral(info, expr); ok {
+		return []string{method}, true
+	}
+	lit, ok := expr.(*ast.CompositeLit)
+	if !ok || len(lit.Elts) == 0 {
+		return nil, false
+	}
+	var methods []string
+	for _, elt := range lit.Elts {
+		method, ok := MethodLiteral(info, elt)
+		if !ok {
+			return nil, false
+		}
+		methods = append(methods, method)
+	}
+	return methods, true
+}
+
+// methodRoutes returns a route to handler guarded by middleware at path for
+// each of methods.
+func methodRoutes(methods []string, path string, handler ast.Expr, middleware []ast.Expr) []Route {
+	var routes []Route
+	for _, method := range methods {
+		routes = append(routes, Route{Method: method, Path: path, Handler: handler, Middleware: middleware})
+	}
+	return routes
+}
+
+// groupCall parses a call creating a router group from a path prefix, such as
+// r.Group("/v1"). The arguments following the prefix are the middleware of
+// the group.
+func groupCall(call *ast.CallExpr, info *types.Info, name string) (Call, bool) {
+	router, method, ok := methodCall(call)
+	if !ok || method != name || len(call.Args) == 0 {
+		return Call{}, false
+	}
+	path, unresolved, ok := pathArg(info, call.Args[0])
+	if !ok {
+		return Call{}, false
+	}
+	return Call{Kind: Group, Router: router, Path: path, Unresolved: unresolved, Middleware: call.Args[1:]}, true
+}
+
+// useCall parses a call adding middleware to a router, such as r.Use(auth).
+// Middleware mounted at a path, as in Fiber's app.Use("/api", auth), is not
+// recognized.
+func useCall(call *ast.CallExpr, info *types.Info) (Call, bool) {
+	router, name, ok := methodCall(call)
+	if !ok || name != "Use" || len(call.Args) == 0 {
+		return Call{}, false
+	}
+	for _, arg := range call.Args {
+		if _, ok := arg.(*ast.BasicLit); ok || isString(info, arg) {
+			return Call{}, false
+		}
+	}
+	return Call{Kind: Use, Router: router, Middleware: call.Args}, true
+}
@@ -0,0 +1,127 @@
+package framework
+
+import (
+	"go/ast"
+	"go/types"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseServeMuxPattern(t *testing.T) {
+	testCases := []struct {
+		pattern       string
+		expectedRoute Route
+		expectedOK    bool
+	}{
+		{pattern: "GET /items/{id}", expectedRoute: Route{Method: "GET", Path: "/items/{id}", Params: []string{"id"}}, expectedOK: true},
+		{pattern: "DELETE\tapi.example.com/items/{id}/{rest...}", expectedRoute: Route{Method: "DELETE", Host: "api.example.com", Path: "/items/{id}/{rest}", Params: []string{"id", "rest"}}, expectedOK: true},
+		{pattern: "/static/", expectedRoute: Route{Method: MethodAny, Path: "/static/"}, expectedOK: true},
+		{pattern: "GET", expectedOK: false},
+		{pattern: "GET /a /b", expectedOK: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.pattern, func(t *testing.T) {
+			route, ok := ParseServeMuxPattern(tc.pattern)
+			assert.Equal(t, tc.expectedOK, ok)
+			if ok {
+				assert.Equal(t, tc.expectedRoute, route)
+			}
+		})
+	}
+}
+
+func TestNormalizeWildcards(t *testing.T) {
+	path, params := NormalizeWildcards("/years/{year:[0-9]{4}}/posts/{slug}/{rest...}/{$}")
+	assert.Equal(t, "/years/{year}/posts/{slug}/{rest}/", path)
+	assert.Equal(t, []string{"year", "slug", "rest"}, params)
+}
+
+func TestStringValue(t *testing.T) {
+	file, info := parseSource(t, `package main
+
+import (
+	"path"
+
+	"example.com/app/config"
+)
+
+const (
+	PathUsers = "/users"
+	apiPrefix = "/api" + "/v1"
+)
+
+var prefix = "/dynamic"
+
+var paths = []any{
+	"/items",
+	PathUsers,
+	apiPrefix + PathUsers + "/:id",
+	path.Join(apiPrefix, "orders", "/"),
+	(PathUsers),
+	prefix + "/items",
+	config.BasePath,
+	path.Join(prefix, "items"),
+}
+`)
+
+	var got []string
+	ast.Inspect(file, func(node ast.Node) bool {
+		if lit, ok := node.(*ast.CompositeLit); ok {
+			for _, elt := range lit.Elts {
+				if value, ok := StringValue(info, elt); ok {
+					got = append(got, value)
+				} else {
+					got = append(got, "?"+types.ExprString(elt))
+				}
+			}
+			return false
+		}
+		return true
+	})
+	assert.Equal(t, []string{
+		"/items",
+		"/users",
+		"/api/v1/users/:id",
+		"/api/v1/orders",
+		"/users",
+		"?prefix + \"/items\"",
+		"?config.BasePath",
+		"?path.Join(prefix, \"items\")",
+	}, got)
+}
+
+func TestMethodLiteral(t *testing.T) {
+	file, info := parseSource(t, `package main
+
+import (
+	"net/http"
+
+	"example.com/app/auth"
+)
+
+var methods = []any{
+	"post",
+	http.MethodGet,
+	auth.MethodBasic,
+	http.StatusOK,
+}
+`)
+
+	var got []string
+	ast.Inspect(file, func(node ast.Node) bool {
+		if lit, ok := node.(*ast.CompositeLit); ok {
+			for _, elt := range lit.Elts {
+				if method, ok := MethodLiteral(info, elt); ok {
+					got = append(got, method)
+				} else {
+					got = append(got, "?"+types.ExprString(elt))
+				}
+			}
+			return false
+		}
+		return true
+	})
+	assert.Equal(t, []string{"POST", "GET", "?auth.MethodBasic", "?http.StatusOK"}, got)
+}
@@ -0,0 +1,68 @@
+package framework
+
+import (
+	"go/ast"
+	"go/types"
+)
+
+var httpImportPaths = []string{"net/http"}
+
+// httpFramework adapts net/http: func(w http.ResponseWriter, r *http.Request)
+// handlers registered on a ServeMux with mux.HandleFunc(pattern, h), where the
+// pattern may carry a method and a host.
+type httpFramework struct{}
+
+func (httpFramework) Name() string {
+	return "net/http"
+}
+
+func (httpFramework) ImportPaths() []string {
+	return httpImportPaths
+}
+
+func (httpFramework) IsHandler(fnType *ast.FuncType, info *types.Info) bool {
+	params := FieldTypes(fnType.Params)
+	return len(params) == 2 && fnType.Results.NumFields() == 0 &&
+		IsNamedType(info, params[0], "ResponseWriter", httpImportPaths) &&
+		IsPointerTo(info, params[1], "Request", httpImportPaths)
+}
+
+func (httpFramework) IsHandlerType(expr ast.Expr, info *types.Info) bool {
+	return IsNamedType(info, expr, "HandlerFunc", httpImportPaths)
+}
+
+func (httpFramework) RouterCall(call *ast.CallExpr, info *types.Info) (Call, bool) {
+	return patternRoute(call, info)
+}
+
+func (httpFramework) PromptHints() string {
+	return `This is a net/http handler. Read it as follows:
+- r.PathValue("name"), chi.URLParam(r, "name") and mux.Vars(r)["name"] read the path parameter name.
+- r.URL.Query().Get("name") and r.FormValue("name") read the query parameter name.
+- json.NewDecoder(r.Body).Decode(&v) parses the request body into v.
+- w.WriteHeader(code) sets the response code; json.NewEncoder(w).Encode(v) writes v as the body, with 200 unless another code was written first.
+- http.Error(w, msg, code) responds with an error.
+`
+}
+
+// patternRoute parses a registration of a ServeMux pattern, such as
+// mux.HandleFunc("GET /items/{id}", h).
+func patternRoute(call *ast.CallExpr, info *types.Info) (Call, bool) {
+	router, name, ok := methodCall(call)
+	if !ok || (name != "HandleFunc" && name != "Handle") || len(call.Args) != 2 {
+		return Call{}, false
+	}
+	pattern, unresolved, ok := pathArg(info, call.Args[0])
+	if !ok {
+		return Call{}, false
+	}
+	if unresolved != nil {
+		return Call{Kind: Registration, Router: router, Routes: []Route{{Method: MethodAny, Handler: call.Args[1]}}, Unresolved: unresolved}, true
+	}
+	route, ok := ParseServeMuxPattern(pattern)
+	if !ok {
+		return Call{}, false
+	}
+	route.Handler = call.Args[1]
+	return Call{Kind: Registration, Router: router, Routes: []Route{route}}, true
+}
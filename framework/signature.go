@@ -0,0 +1,57 @@
+package framework
+
+import (
+	"go/ast"
+	"go/types"
+	"slices"
+)
+
+// signatureFramework adapts handlers recognized by their signature alone, such
+// as func(c *gin.Context) error adapted to Gin when registered. Their routes
+// are registered through the frameworks they are adapted to.
+type signatureFramework struct {
+	name    string
+	params  []string
+	results []string
+	hints   string
+}
+
+// NewSignature returns a framework whose handlers are the functions taking
+// params and returning results, given as types qualified by their import path
+// such as "*github.com/gin-gonic/gin.Context" or "error".
+func NewSignature(name string, params, results []string, hints string) Framework {
+	return signatureFramework{name: name, params: params, results: results, hints: hints}
+}
+
+func (f signatureFramework) Name() string {
+	return f.name
+}
+
+func (signatureFramework) ImportPaths() []string {
+	return nil
+}
+
+func (f signatureFramework) IsHandler(fnType *ast.FuncType, info *types.Info) bool {
+	return slices.Equal(typeStrings(info, FieldTypes(fnType.Params)), f.params) &&
+		slices.Equal(typeStrings(info, FieldTypes(fnType.Results)), f.results)
+}
+
+func (signatureFramework) IsHandlerType(ast.Expr, *types.Info) bool {
+	return false
+}
+
+func (signatureFramework) RouterCall(*ast.CallExpr, *types.Info) (Call, bool) {
+	return Call{}, false
+}
+
+func (f signatureFramework) PromptHints() string {
+	return f.hints
+}
+
+func typeStrings(info *types.Info, exprs []ast.Expr) []string {
+	var strs []string
+	for _, expr := range exprs {
+		strs = append(strs, TypeString(info, expr))
+	}
+	return strs
+}
@@ -0,0 +1,123 @@
+package framework
+
+import (
+	"go/ast"
+	"go/types"
+	"strconv"
+)
+
+// wrappers holds the functions that adapt a handler at registration, as in
+// r.GET("/x", api.Wrap(h)), by name qualified by import path.
+var wrappers = map[string]bool{}
+
+// RegisterWrapper declares name, such as "example.com/app/api.Wrap", as a
+// function that adapts the handler passed as its first argument. Functions of
+// the package that registers the routes may be given by name alone.
+func RegisterWrapper(name string) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	wrappers[name] = true
+}
+
+// IsWrapper reports whether fn is a registered wrapper function.
+func IsWrapper(fn *ast.FuncDecl, info *types.Info) bool {
+	if fn.Recv != nil {
+		return false
+	}
+	mu.RLock()
+	defer mu.RUnlock()
+
+	if obj := info.Defs[fn.Name]; obj != nil && obj.Pkg() != nil && wrappers[obj.Pkg().Path()+"."+fn.Name.Name] {
+		return true
+	}
+	return wrappers[fn.Name.Name]
+}
+
+// adapters maps the functions of the supported frameworks that adapt a
+// handler, such as http.HandlerFunc(h) and gin.WrapF(h), to the index of the
+// handler argument.
+var adapters = map[string]int{
+	"net/http.HandlerFunc":                                           0,
+	"net/http.StripPrefix":                                           1,
+	"net/http.TimeoutHandler":                                        0,
+	"net/http.MaxBytesHandler":                                       0,
+	"github.com/gin-gonic/gin.WrapF":                                 0,
+	"github.com/gin-gonic/gin.WrapH":                                 0,
+	"github.com/labstack/echo/v4.WrapHandler":                        0,
+	"github.com/labstack/echo.WrapHandler":                           0,
+	"github.com/gofiber/adaptor/v2.HTTPHandlerFunc":                  0,
+	"github.com/gofiber/adaptor/v2.HTTPHandler":                      0,
+	"github.com/gofiber/fiber/v2/middleware/adaptor.HTTPHandlerFunc": 0,
+	"github.com/gofiber/fiber/v2/middleware/adaptor.HTTPHandler":     0,
+}
+
+// chainMethods are the methods ending a middleware chain with the handler, as
+// in alice.New(auth, logging).Then(h).
+var chainMethods = map[string]bool{
+	"Then":     true,
+	"ThenFunc": true,
+}
+
+// Unwrap returns the handler passed to the wrappers, adapters and middleware
+// chains expr is a call of, or expr itself. file is the file expr appears in.
+func Unwrap(file *ast.File, expr ast.Expr) ast.Expr {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	for {
+		if paren, ok := expr.(*ast.ParenExpr); ok {
+			expr = paren.X
+			continue
+		}
+		call, ok := expr.(*ast.CallExpr)
+		if !ok || len(call.Args) == 0 {
+			return expr
+		}
+
+		name := calledName(file, call)
+		if i, ok := adapters[name]; ok && i < len(call.Args) {
+			expr = call.Args[i]
+		} else if wrappers[name] {
+			expr = call.Args[0]
+		} else if selExpr, ok := call.Fun.(*ast.SelectorExpr); ok && chainMethods[selExpr.Sel.Name] && len(call.Args) == 1 {
+			expr = call.Args[0]
+		} else {
+			return expr
+		}
+	}
+}
+
+// calledName returns the name of the function called by call, qualified by
+// its import path if it is declared in another package.
+func calledName(file *ast.File, call *ast.CallExpr) string {
+	switch fun := call.Fun.(type) {
+	case *ast.Ident:
+		return fun.Name
+	case *ast.SelectorExpr:
+		if ident, ok := fun.X.(*ast.Ident); ok {
+			if path, ok := ImportPath(file, ident.Name); ok {
+				return path + "." + fun.Sel.Name
+			}
+		}
+	}
+	return ""
+}
+
+// ImportPath returns the path of the package file imports as name.
+func ImportPath(file *ast.File, name string) (string, bool) {
+	for _, spec := range file.Imports {
+		path, err := strconv.Unquote(spec.Path.Value)
+		if err != nil {
+			continue
+		}
+		importName := defaultPackageName(path)
+		if spec.Name != nil {
+			importName = spec.Name.Name
+		}
+		if importName == name {
+			return path, true
+		}
+	}
+	return "", false
+}
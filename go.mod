@@ -1,10 +1,12 @@
 module github.com/insectkorea/swagGPT
 
-go 1.19
+go 1.26.0
 
 require (
 	github.com/stretchr/testify v1.7.0
 	github.com/urfave/cli/v2 v2.27.2
+	golang.org/x/tools v0.50.0
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
@@ -12,9 +14,10 @@ require (
 	github.com/mitchellh/colorstring v0.0.0-20190213212951-d06e56a500db // indirect
 	github.com/pmezard/go-difflib v1.0.0 // indirect
 	github.com/rivo/uniseg v0.4.7 // indirect
-	golang.org/x/sys v0.20.0 // indirect
+	golang.org/x/mod v0.41.0 // indirect
+	golang.org/x/sync v0.23.0 // indirect
+	golang.org/x/sys v0.48.0 // indirect
 	golang.org/x/term v0.20.0 // indirect
-	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
 
 require (
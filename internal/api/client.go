@@ -2,14 +2,13 @@ package api
 
 import (
 	"context"
-	"fmt"
 
 	openai "github.com/sashabaranov/go-openai"
 )
 
 // Client is an interface representing the OpenAI client.
 type Client interface {
-	GenerateSwaggerComment(functionName, functionContent, model string, routeString string) (string, error)
+	GenerateSwaggerComment(functionName, functionContent, model string, routeString string, hints string) (string, error)
 }
 
 // OpenAIClient is a struct that implements the Client interface.
@@ -25,18 +24,15 @@ func NewOpenAIClient(apiKey string) *OpenAIClient {
 }
 
 // GenerateSwaggerComment generates Swagger comments using OpenAI API.
-func (c *OpenAIClient) GenerateSwaggerComment(functionName, functionContent, model string, routeString string) (string, error) {
+func (c *OpenAIClient) GenerateSwaggerComment(functionName, functionContent, model string, routeString string, hints string) (string, error) {
 	messages := []openai.ChatCompletionMessage{
 		{
 			Role:    "system",
 			Content: systemPrompt,
 		},
 		{
-			Role: "user",
-			Content: fmt.Sprintf(userPromptTemplate,
-				functionContent,
-				routeString,
-			),
+			Role:    "user",
+			Content: userPrompt(functionContent, routeString, hints),
 		},
 	}
 
@@ -9,25 +9,26 @@ const systemPrompt = `
 You are a helpful assistant for generating Swagger annotation comments for Go handler functions.
 `
 
+// userPromptTemplate is the prompt for a handler of any framework, whose
+// idioms are described by the hints of its adapter.
 const userPromptTemplate = `
-Here is an example of a Go handler function:
-Make sure to follow the format strictly. Use struct that are defined in the codebase.
-// ListAccounts lists all existing accounts
+Generate Swagger comments for the following Go handler function:
+%s
+%s
+Make sure to follow the format of swag annotations strictly, as in:
+// GetItem godoc
 //
-//  @Summary      List accounts
-//  @Description  get accounts
-//  @Tags         accounts
+//  @Summary      Get an item
+//  @Description  get an item by its ID
+//  @Tags         items
 //  @Accept       json
 //  @Produce      json
-//  @Param        q    query     string  false  "name search by q"  Format(email)
-//  @Success      200  {array}   model.Account
-//  @Failure      400  {object}  httputil.HTTPError
-//  @Failure      404  {object}  httputil.HTTPError
-//  @Failure      500  {object}  httputil.HTTPError
-//  @Router       /accounts [get]
-
-Generate Swagger comments for the following function:
-%s
+//  @Param        id   path      int  true  "Item ID"
+//  @Success      200  {object}  Item
+//  @Failure      404  {object}  ErrorResponse
+//  @Router       /items/{id} [get]
+
+Use the structs that are defined in the codebase for the request, response and error bodies.
 Do not add any explanation and just return the Swagger comments. Do not wrap it in Markdown. Do not return the function itself.
 If it is not a handler function(e.g. a function in a test file or a helper function), return nothing.
 
@@ -35,13 +36,19 @@ Here are candidate routes. Parse route according to the format:
 %s
 `
 
-// EstimateTokens estimates the number of tokens for generating Swagger comments.
-func EstimateTokens(functionContent string, routes string) int {
-	userPrompt := fmt.Sprintf(userPromptTemplate,
+// userPrompt builds the user prompt for the given function, candidate routes
+// and framework hints.
+func userPrompt(functionContent, routes, hints string) string {
+	return fmt.Sprintf(userPromptTemplate,
 		functionContent,
+		hints,
 		routes,
 	)
-	prompt := userPrompt + systemPrompt
+}
+
+// EstimateTokens estimates the number of tokens for generating Swagger comments.
+func EstimateTokens(functionContent string, routes string, hints string) int {
+	prompt := userPrompt(functionContent, routes, hints) + systemPrompt
 
 	// Estimate tokens based on number of words
 	words := len(strings.Split(prompt, " "))
@@ -0,0 +1,27 @@
+package api
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestUserPromptIncludesFrameworkHints(t *testing.T) {
+	hints := "This is a Fiber handler. c.BodyParser(&v) parses the request body into v."
+
+	prompt := userPrompt("func GetUser(c *fiber.Ctx) error {}", "/users/:id [get]", hints)
+	if !strings.Contains(prompt, hints) {
+		t.Fatalf("Expected prompt to contain the framework hints, got %q", prompt)
+	}
+	if EstimateTokens("func GetUser(c *fiber.Ctx) error {}", "", hints) <= EstimateTokens("func GetUser(c *fiber.Ctx) error {}", "", "") {
+		t.Fatalf("Expected hints to be counted in the token estimate")
+	}
+}
+
+func TestUserPromptIsFrameworkNeutral(t *testing.T) {
+	prompt := userPrompt("func GetUser(w http.ResponseWriter, r *http.Request) {}", "/users/{id} [get]", "")
+	for _, word := range []string{"gin", "echo", "fiber", "httputil"} {
+		if strings.Contains(strings.ToLower(prompt), word) {
+			t.Errorf("Expected no mention of %q without hints, got %q", word, prompt)
+		}
+	}
+}
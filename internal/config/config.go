@@ -0,0 +1,169 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/insectkorea/swagGPT/framework"
+	"github.com/insectkorea/swagGPT/internal/model"
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultFile is the name of the config file looked up in the scanned directory.
+const DefaultFile = ".swaggpt.yaml"
+
+// Config is the swaggpt configuration.
+type Config struct {
+	// Handlers declares handler signatures in addition to those of the
+	// supported frameworks.
+	Handlers []HandlerSignature `yaml:"handlers"`
+	// Wrappers lists the functions that adapt a handler at registration, as
+	// in r.GET("/x", api.Wrap(h)), qualified by import path.
+	Wrappers []string `yaml:"wrappers"`
+	// Middleware maps the middleware guarding routes to the security schemes
+	// and failure responses they add to the routes' annotations.
+	Middleware []Middleware `yaml:"middleware"`
+	// BasePath is the @BasePath of the API, which @Router paths are relative
+	// to. It is read from the @BasePath annotation of the scanned files if
+	// empty.
+	BasePath string `yaml:"basePath"`
+}
+
+// Middleware declares the security schemes and failure responses of the
+// routes guarded by the middleware Name. Name is the function applied as
+// middleware, or called to build it as in auth.RequireJWT(), qualified by
+// import path as in "example.com/app/auth.RequireJWT". It may be given by name
+// alone.
+type Middleware struct {
+	Name string `yaml:"name"`
+	// Security lists the security schemes of the routes, as in "BearerAuth".
+	Security []string `yaml:"security"`
+	// Failures lists @Failure annotations without the tag, as in
+	// `401 {object} api.Error "Unauthorized"`.
+	Failures []string `yaml:"failures"`
+}
+
+// HandlerSignature declares the functions taking Params and returning Results
+// as handlers. Types are qualified by import path, as in
+// "*github.com/gin-gonic/gin.Context".
+type HandlerSignature struct {
+	Name    string   `yaml:"name"`
+	Params  []string `yaml:"params"`
+	Results []string `yaml:"results"`
+	// Hints are notes on the handlers added to the prompt.
+	Hints string `yaml:"hints"`
+}
+
+// Load reads the config from path. If path is empty, the default config file
+// of dir is read if it exists.
+func Load(path string, dir string) (*Config, error) {
+	if path == "" {
+		path = filepath.Join(dir, DefaultFile)
+		if _, err := os.Stat(path); os.IsNotExist(err) {
+			return &Config{}, nil
+		}
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config %s: %v", path, err)
+	}
+
+	var cfg Config
+	if err := yaml.Unmarshal(content, &cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse config %s: %v", path, err)
+	}
+	for i, handler := range cfg.Handlers {
+		if len(handler.Params) == 0 {
+			return nil, fmt.Errorf("invalid config %s: handler %d has no params", path, i+1)
+		}
+	}
+	for i, mw := range cfg.Middleware {
+		if mw.Name == "" {
+			return nil, fmt.Errorf("invalid config %s: middleware %d has no name", path, i+1)
+		}
+		for _, failure := range mw.Failures {
+			if _, ok := failureCode(failure); !ok {
+				return nil, fmt.Errorf("invalid config %s: failure %q of middleware %s does not start with a status code", path, failure, mw.Name)
+			}
+		}
+	}
+	return &cfg, nil
+}
+
+// Apply registers the handler signatures and wrappers of cfg.
+func (cfg *Config) Apply() {
+	for i, handler := range cfg.Handlers {
+		name := handler.Name
+		if name == "" {
+			name = fmt.Sprintf("handler-%d", i+1)
+		}
+		framework.Register(framework.NewSignature(name, handler.Params, handler.Results, handler.Hints))
+	}
+	for _, wrapper := range cfg.Wrappers {
+		framework.RegisterWrapper(wrapper)
+	}
+}
+
+// Annotate sets the security schemes and failure responses of routes from the
+// middleware guarding them. Schemes are listed in the order their middleware
+// applies, and failures by status code, the first middleware declaring a code
+// taking precedence.
+func (cfg *Config) Annotate(routes []model.Route) {
+	for i := range routes {
+		route := &routes[i]
+		route.Security, route.Failures = nil, nil
+		codes := map[int]bool{}
+		for _, name := range route.Middleware {
+			for _, mw := range cfg.Middleware {
+				if !mw.matches(name) {
+					continue
+				}
+				for _, scheme := range mw.Security {
+					if !slices.Contains(route.Security, scheme) {
+						route.Security = append(route.Security, scheme)
+					}
+				}
+				for _, failure := range mw.Failures {
+					code, _ := failureCode(failure)
+					if !codes[code] {
+						codes[code] = true
+						route.Failures = append(route.Failures, failure)
+					}
+				}
+			}
+		}
+		slices.SortStableFunc(route.Failures, func(a, b string) int {
+			codeA, _ := failureCode(a)
+			codeB, _ := failureCode(b)
+			return codeA - codeB
+		})
+	}
+}
+
+// matches reports whether mw declares the middleware called name, by its
+// qualified name or by name alone.
+func (mw Middleware) matches(name string) bool {
+	if mw.Name == name {
+		return true
+	}
+	return !strings.Contains(mw.Name, ".") && strings.HasSuffix(name, "."+mw.Name)
+}
+
+// failureCode returns the status code a @Failure annotation starts with.
+// swag's "default" response sorts after every code.
+func failureCode(failure string) (int, bool) {
+	fields := strings.Fields(failure)
+	if len(fields) == 0 {
+		return 0, false
+	}
+	if fields[0] == "default" {
+		return 1000, true
+	}
+	code, err := strconv.Atoi(fields[0])
+	return code, err == nil
+}
@@ -0,0 +1,120 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/insectkorea/swagGPT/internal/model"
+)
+
+func TestLoad(t *testing.T) {
+	dir := t.TempDir()
+	content := `handlers:
+  - name: appctx
+    params: ["*example.com/app/appctx.Context"]
+    results: [any, error]
+wrappers:
+  - example.com/app/api.Wrap
+middleware:
+  - name: example.com/app/auth.RequireJWT
+    security: [BearerAuth]
+    failures:
+      - 401 {object} api.Error "Unauthorized"
+basePath: /api/v1
+`
+	if err := os.WriteFile(filepath.Join(dir, DefaultFile), []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	cfg, err := Load("", dir)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	expected := &Config{
+		Handlers: []HandlerSignature{
+			{Name: "appctx", Params: []string{"*example.com/app/appctx.Context"}, Results: []string{"any", "error"}},
+		},
+		Wrappers: []string{"example.com/app/api.Wrap"},
+		Middleware: []Middleware{
+			{Name: "example.com/app/auth.RequireJWT", Security: []string{"BearerAuth"}, Failures: []string{`401 {object} api.Error "Unauthorized"`}},
+		},
+		BasePath: "/api/v1",
+	}
+	if !reflect.DeepEqual(cfg, expected) {
+		t.Fatalf("Expected %+v, got %+v", expected, cfg)
+	}
+}
+
+func TestLoadWithoutConfig(t *testing.T) {
+	cfg, err := Load("", t.TempDir())
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(cfg.Handlers) != 0 || len(cfg.Wrappers) != 0 {
+		t.Fatalf("Expected an empty config, got %+v", cfg)
+	}
+
+	if _, err := Load(filepath.Join(t.TempDir(), "missing.yaml"), ""); err == nil {
+		t.Fatalf("Expected an error for a missing config file")
+	}
+}
+
+func TestLoadInvalidHandler(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "swaggpt.yaml")
+	if err := os.WriteFile(path, []byte("handlers:\n  - results: [error]\n"), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+	if _, err := Load(path, ""); err == nil {
+		t.Fatalf("Expected an error for a handler without params")
+	}
+}
+
+func TestLoadInvalidMiddleware(t *testing.T) {
+	for _, content := range []string{
+		"middleware:\n  - security: [BearerAuth]\n",
+		"middleware:\n  - name: RequireJWT\n    failures: [Unauthorized]\n",
+	} {
+		path := filepath.Join(t.TempDir(), "swaggpt.yaml")
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write config: %v", err)
+		}
+		if _, err := Load(path, ""); err == nil {
+			t.Fatalf("Expected an error for %q", content)
+		}
+	}
+}
+
+func TestAnnotate(t *testing.T) {
+	cfg := &Config{
+		Middleware: []Middleware{
+			{Name: "example.com/app/auth.RequireJWT", Security: []string{"BearerAuth"}, Failures: []string{`401 {object} api.Error "Unauthorized"`}},
+			{Name: "requireAdmin", Security: []string{"BearerAuth"}, Failures: []string{`403 {object} api.Error "Forbidden"`, `401 {string} string "Unauthorized"`}},
+			{Name: "example.com/app/limit.Limit", Failures: []string{`default {object} api.Error`, `429 {object} api.Error "Too Many Requests"`}},
+		},
+	}
+	routes := []model.Route{
+		{Method: "GET", Pattern: "/health"},
+		{Method: "GET", Pattern: "/me", Middleware: []string{"example.com/app/limit.Limit", "example.com/app/auth.RequireJWT"}},
+		{Method: "DELETE", Pattern: "/users/:id", Middleware: []string{"example.com/app/auth.RequireJWT", "example.com/app.requireAdmin"}},
+	}
+	cfg.Annotate(routes)
+
+	expected := []model.Route{
+		{Method: "GET", Pattern: "/health"},
+		{
+			Method: "GET", Pattern: "/me", Middleware: routes[1].Middleware,
+			Security: []string{"BearerAuth"},
+			Failures: []string{`401 {object} api.Error "Unauthorized"`, `429 {object} api.Error "Too Many Requests"`, `default {object} api.Error`},
+		},
+		{
+			Method: "DELETE", Pattern: "/users/:id", Middleware: routes[2].Middleware,
+			Security: []string{"BearerAuth"},
+			Failures: []string{`401 {object} api.Error "Unauthorized"`, `403 {object} api.Error "Forbidden"`},
+		},
+	}
+	if !reflect.DeepEqual(routes, expected) {
+		t.Fatalf("Expected %+v, got %+v", expected, routes)
+	}
+}
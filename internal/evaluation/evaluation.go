@@ -0,0 +1,266 @@
+// Package evaluation measures how well a matcher guesses the routes of
+// handlers, against fixtures of handlers, routes and the routes each handler
+// is known to serve.
+package evaluation
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/insectkorea/swagGPT/internal/mapping"
+	"github.com/insectkorea/swagGPT/internal/matcher"
+	"github.com/insectkorea/swagGPT/internal/model"
+	"gopkg.in/yaml.v3"
+)
+
+// Fixture is a set of routes and of handlers with the routes they serve.
+type Fixture struct {
+	// Name identifies the fixture in reports; it defaults to its file name.
+	Name string `yaml:"name"`
+	// Routes are the routes handlers are matched to, as "METHOD /path".
+	Routes []string `yaml:"routes"`
+	Cases  []Case   `yaml:"cases"`
+}
+
+// Case is a handler and the routes it serves.
+type Case struct {
+	Handler  string `yaml:"handler"`
+	Receiver string `yaml:"receiver"`
+	Package  string `yaml:"package"`
+	// Expected are the routes the handler serves, as "METHOD /path", or none
+	// if it serves no route.
+	Expected []string `yaml:"expected"`
+}
+
+// Matcher returns the candidate routes of handler among routes, best first.
+type Matcher func(handler matcher.Handler, routes []model.Route) []matcher.Candidate
+
+// DefaultMatcher returns the Matcher of MatchHandlerToRoute, selecting
+// candidates with opts.
+func DefaultMatcher(opts matcher.Options) Matcher {
+	return func(handler matcher.Handler, routes []model.Route) []matcher.Candidate {
+		return matcher.MatchHandlerToRoute(handler, routes, opts)
+	}
+}
+
+// Report sums up how a matcher did on fixtures.
+type Report struct {
+	// K is the number of candidates a top-k hit is looked for in, or 0 for
+	// all of them.
+	K     int `json:"k"`
+	Cases int `json:"cases"`
+	// Top1 and TopK count the cases whose first candidate, or one of
+	// whose first K candidates, is a route the handler serves. A handler
+	// serving no route hits if it gets no candidate.
+	Top1 int `json:"top1"`
+	TopK int `json:"topK"`
+	// TruePositives counts the candidates the handler serves,
+	// FalsePositives those it does not, and FalseNegatives the routes the
+	// handler serves that are not among its candidates.
+	TruePositives  int       `json:"truePositives"`
+	FalsePositives int       `json:"falsePositives"`
+	FalseNegatives int       `json:"falseNegatives"`
+	Failures       []Failure `json:"failures"`
+}
+
+// Failure is a case whose first candidate is not a route the handler serves.
+type Failure struct {
+	Fixture  string   `json:"fixture"`
+	Handler  string   `json:"handler"`
+	Expected []string `json:"expected"`
+	// Got are the candidates, as in "GET /users (0.85)".
+	Got []string `json:"got"`
+}
+
+// Top1Accuracy returns the share of cases with a top-1 hit.
+func (r *Report) Top1Accuracy() float64 {
+	return ratio(r.Top1, r.Cases)
+}
+
+// TopKAccuracy returns the share of cases with a top-k hit.
+func (r *Report) TopKAccuracy() float64 {
+	return ratio(r.TopK, r.Cases)
+}
+
+// Precision returns the share of candidates that are routes their handler
+// serves.
+func (r *Report) Precision() float64 {
+	return ratio(r.TruePositives, r.TruePositives+r.FalsePositives)
+}
+
+// Recall returns the share of the routes handlers serve that are among their
+// candidates.
+func (r *Report) Recall() float64 {
+	return ratio(r.TruePositives, r.TruePositives+r.FalseNegatives)
+}
+
+// ratio returns n / total, or 0 if total is 0.
+func ratio(n, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(n) / float64(total)
+}
+
+// LoadFixtures reads the fixtures of paths, which are fixture files or
+// directories of them, with a .yaml, .yml or .json extension.
+func LoadFixtures(paths []string) ([]Fixture, error) {
+	var fixtures []Fixture
+	for _, path := range paths {
+		files := []string{path}
+		if info, err := os.Stat(path); err != nil {
+			return nil, fmt.Errorf("failed to read fixtures %s: %v", path, err)
+		} else if info.IsDir() {
+			files = nil
+			for _, pattern := range []string{"*.yaml", "*.yml", "*.json"} {
+				matches, err := filepath.Glob(filepath.Join(path, pattern))
+				if err != nil {
+					return nil, fmt.Errorf("failed to list fixtures in %s: %v", path, err)
+				}
+				files = append(files, matches...)
+			}
+			slices.Sort(files)
+		}
+
+		for _, file := range files {
+			fixture, err := loadFixture(file)
+			if err != nil {
+				return nil, err
+			}
+			fixtures = append(fixtures, fixture)
+		}
+	}
+	return fixtures, nil
+}
+
+// loadFixture reads the fixture file at path.
+func loadFixture(path string) (Fixture, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return Fixture{}, fmt.Errorf("failed to read fixture %s: %v", path, err)
+	}
+
+	var fixture Fixture
+	if err := yaml.Unmarshal(content, &fixture); err != nil {
+		return Fixture{}, fmt.Errorf("failed to parse fixture %s: %v", path, err)
+	}
+	if fixture.Name == "" {
+		fixture.Name = filepath.Base(path)
+	}
+	for _, route := range fixture.Routes {
+		if _, _, ok := mapping.ParseRoute(route); !ok {
+			return Fixture{}, fmt.Errorf("invalid fixture %s: route %q is not of the form \"METHOD /path\"", path, route)
+		}
+	}
+	for i, c := range fixture.Cases {
+		if c.Handler == "" {
+			return Fixture{}, fmt.Errorf("invalid fixture %s: case %d has no handler", path, i+1)
+		}
+		for _, route := range c.Expected {
+			if _, _, ok := mapping.ParseRoute(route); !ok {
+				return Fixture{}, fmt.Errorf("invalid fixture %s: expected route %q of %s is not of the form \"METHOD /path\"", path, route, c.Handler)
+			}
+		}
+	}
+	return fixture, nil
+}
+
+// Evaluate runs match on the cases of fixtures and reports how many of them
+// it gets right, looking for top-k hits in the first k candidates, or all of
+// them if k is 0. Routes are compared by method and path, whatever the syntax
+// of their parameters, so :id and {id} are the same.
+func Evaluate(fixtures []Fixture, match Matcher, k int) *Report {
+	report := &Report{K: k}
+	for _, fixture := range fixtures {
+		var routes []model.Route
+		for _, r := range fixture.Routes {
+			method, path, _ := mapping.ParseRoute(r)
+			routes = append(routes, model.Route{Method: method, Path: path, Pattern: path})
+		}
+
+		for _, c := range fixture.Cases {
+			var expected []string
+			for _, r := range c.Expected {
+				method, path, _ := mapping.ParseRoute(r)
+				expected = append(expected, mapping.FormatRoute(model.Route{Method: method, Path: path}))
+			}
+			handler := matcher.Handler{Name: c.Handler, Receiver: c.Receiver, Package: c.Package}
+			candidates := match(handler, routes)
+			report.add(fixture.Name, handler, expected, candidates)
+		}
+	}
+	return report
+}
+
+// add counts the case of handler, serving the expected routes, that got
+// candidates.
+func (r *Report) add(fixture string, handler matcher.Handler, expected []string, candidates []matcher.Candidate) {
+	r.Cases++
+
+	var got []string
+	none := len(expected) == 0 && len(candidates) == 0
+	top1, topK := none, none
+	for i, candidate := range candidates {
+		route := mapping.FormatRoute(candidate.Route)
+		got = append(got, fmt.Sprintf("%s (%.2f)", route, candidate.Score))
+		if !slices.Contains(expected, route) {
+			r.FalsePositives++
+			continue
+		}
+		r.TruePositives++
+		top1 = top1 || i == 0
+		topK = topK || r.K == 0 || i < r.K
+	}
+	for _, route := range expected {
+		if !slices.ContainsFunc(candidates, func(c matcher.Candidate) bool { return mapping.FormatRoute(c.Route) == route }) {
+			r.FalseNegatives++
+		}
+	}
+
+	if top1 {
+		r.Top1++
+	}
+	if topK {
+		r.TopK++
+	}
+	if !top1 {
+		name := handler.Name
+		if handler.Receiver != "" {
+			name = handler.Receiver + "." + name
+		}
+		r.Failures = append(r.Failures, Failure{Fixture: fixture, Handler: name, Expected: expected, Got: got})
+	}
+}
+
+// String formats r as a summary followed by the failed cases.
+func (r *Report) String() string {
+	var b strings.Builder
+	k := "k"
+	if r.K > 0 {
+		k = fmt.Sprint(r.K)
+	}
+	fmt.Fprintf(&b, "Cases:          %d\n", r.Cases)
+	fmt.Fprintf(&b, "Top-1 accuracy: %.1f%% (%d/%d)\n", 100*r.Top1Accuracy(), r.Top1, r.Cases)
+	fmt.Fprintf(&b, "Top-%s accuracy: %.1f%% (%d/%d)\n", k, 100*r.TopKAccuracy(), r.TopK, r.Cases)
+	fmt.Fprintf(&b, "Precision:      %.1f%% (%d/%d)\n", 100*r.Precision(), r.TruePositives, r.TruePositives+r.FalsePositives)
+	fmt.Fprintf(&b, "Recall:         %.1f%% (%d/%d)\n", 100*r.Recall(), r.TruePositives, r.TruePositives+r.FalseNegatives)
+	if len(r.Failures) == 0 {
+		return b.String()
+	}
+
+	fmt.Fprintf(&b, "\nFailed cases:\n")
+	for _, f := range r.Failures {
+		expected, got := strings.Join(f.Expected, ", "), strings.Join(f.Got, ", ")
+		if expected == "" {
+			expected = "none"
+		}
+		if got == "" {
+			got = "none"
+		}
+		fmt.Fprintf(&b, "  %s: %s\n    expected: %s\n    got:      %s\n", f.Fixture, f.Handler, expected, got)
+	}
+	return b.String()
+}
@@ -0,0 +1,110 @@
+package evaluation
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/insectkorea/swagGPT/internal/matcher"
+	"github.com/insectkorea/swagGPT/internal/model"
+)
+
+func TestLoadFixtures(t *testing.T) {
+	fixtures, err := LoadFixtures([]string{"testdata"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(fixtures) != 1 || fixtures[0].Name != "users" {
+		t.Fatalf("Expected the users fixture, got %+v", fixtures)
+	}
+	expected := Case{Handler: "Delete", Receiver: "UserHandler", Expected: []string{"DELETE /api/v1/users/{id}"}}
+	if !reflect.DeepEqual(fixtures[0].Cases[2], expected) {
+		t.Fatalf("Expected case %+v, got %+v", expected, fixtures[0].Cases[2])
+	}
+}
+
+func TestLoadFixturesInvalid(t *testing.T) {
+	for _, content := range []string{
+		"routes: [/users]\n",
+		"routes: [GET /users]\ncases:\n  - expected: [GET /users]\n",
+		"routes: [GET /users]\ncases:\n  - handler: ListUsers\n    expected: [users]\n",
+	} {
+		path := filepath.Join(t.TempDir(), "fixture.yaml")
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write fixture: %v", err)
+		}
+		if _, err := LoadFixtures([]string{path}); err == nil {
+			t.Errorf("Expected an error for %q", content)
+		}
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	fixtures := []Fixture{{
+		Name:   "shop",
+		Routes: []string{"GET /orders", "GET /orders/:id", "POST /orders", "GET /health"},
+		Cases: []Case{
+			{Handler: "ListOrders", Expected: []string{"GET /orders"}},
+			{Handler: "GetOrder", Expected: []string{"GET /orders/{id}"}},
+			{Handler: "CreateOrder", Expected: []string{"POST /orders"}},
+			{Handler: "Metrics"},
+		},
+	}}
+	// Candidates by handler, as indexes of the routes
+	ranked := map[string][]int{
+		"ListOrders":  {0, 1},
+		"GetOrder":    {0, 1},
+		"CreateOrder": {0, 1, 2},
+		"Metrics":     {3},
+	}
+	match := func(handler matcher.Handler, routes []model.Route) []matcher.Candidate {
+		var candidates []matcher.Candidate
+		for _, i := range ranked[handler.Name] {
+			candidates = append(candidates, matcher.Candidate{Route: routes[i], Score: 0.5})
+		}
+		return candidates
+	}
+
+	report := Evaluate(fixtures, match, 2)
+	if report.Cases != 4 || report.Top1 != 1 || report.TopK != 2 {
+		t.Fatalf("Expected 1 top-1 and 2 top-2 hits in 4 cases, got %+v", report)
+	}
+	if report.TruePositives != 3 || report.FalsePositives != 5 || report.FalseNegatives != 0 {
+		t.Fatalf("Unexpected counts %+v", report)
+	}
+	if report.Top1Accuracy() != 0.25 || report.TopKAccuracy() != 0.5 || report.Precision() != 0.375 || report.Recall() != 1 {
+		t.Fatalf("Unexpected metrics %v %v %v %v", report.Top1Accuracy(), report.TopKAccuracy(), report.Precision(), report.Recall())
+	}
+
+	expected := []Failure{
+		{Fixture: "shop", Handler: "GetOrder", Expected: []string{"GET /orders/{id}"}, Got: []string{"GET /orders (0.50)", "GET /orders/{id} (0.50)"}},
+		{Fixture: "shop", Handler: "CreateOrder", Expected: []string{"POST /orders"}, Got: []string{"GET /orders (0.50)", "GET /orders/{id} (0.50)", "POST /orders (0.50)"}},
+		{Fixture: "shop", Handler: "Metrics", Got: []string{"GET /health (0.50)"}},
+	}
+	if !reflect.DeepEqual(report.Failures, expected) {
+		t.Fatalf("Expected failures %+v, got %+v", expected, report.Failures)
+	}
+
+	if report := Evaluate(fixtures, match, 0); report.TopK != 3 {
+		t.Fatalf("Expected 3 hits among all candidates, got %d", report.TopK)
+	}
+}
+
+func TestEvaluateDefaultMatcher(t *testing.T) {
+	fixtures, err := LoadFixtures([]string{"testdata"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	report := Evaluate(fixtures, DefaultMatcher(matcher.DefaultOptions), matcher.DefaultOptions.TopK)
+	if report.Cases != 6 || report.Top1 != 6 || report.TopK != 6 {
+		t.Fatalf("Expected top-1 and top-3 hits in all 6 cases, got %+v", report)
+	}
+	if report.TruePositives != 5 || report.FalsePositives != 8 || report.FalseNegatives != 0 {
+		t.Fatalf("Unexpected counts %+v", report)
+	}
+	if len(report.Failures) != 0 {
+		t.Fatalf("Expected no failures, got %+v", report.Failures)
+	}
+}
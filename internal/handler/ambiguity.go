@@ -0,0 +1,54 @@
+package handler
+
+import (
+	"slices"
+
+	"github.com/insectkorea/swagGPT/internal/matcher"
+	"github.com/insectkorea/swagGPT/internal/model"
+	"github.com/insectkorea/swagGPT/internal/scanner"
+)
+
+// ambiguityMargin is the score difference under which the two best candidates
+// of a handler are too close to pick one.
+const ambiguityMargin = 0.1
+
+// Ambiguity is a handler no route is bound to, whose route is missing or
+// ambiguous.
+type Ambiguity struct {
+	Handler matcher.Handler
+	// Candidates are the ranked candidate routes of Handler, which scored
+	// below the threshold if its route is missing.
+	Candidates []matcher.Candidate
+}
+
+// ScanHandlers returns the handlers selected by opts in files.
+func ScanHandlers(files []File, opts scanner.Options) []matcher.Handler {
+	var scanned []matcher.Handler
+	for _, file := range files {
+		for _, handler := range file.selected(opts) {
+			scanned = append(scanned, matcherHandler(handler))
+		}
+	}
+	return scanned
+}
+
+// Ambiguities returns the handlers of handlers no route is bound to, and which
+// have either no candidate route scoring at least match.MinScore or two best
+// candidates scoring within ambiguityMargin of each other. Each comes with up
+// to match.TopK candidates, whatever their score. Handlers listed in
+// match.Unrouted are skipped.
+func Ambiguities(handlers []matcher.Handler, routes []model.Route, match matcher.Options) []Ambiguity {
+	var ambiguities []Ambiguity
+	for _, h := range handlers {
+		if slices.Contains(match.Unrouted, h) || len(matcher.BoundRoutes(h, routes)) > 0 {
+			continue
+		}
+		candidates := matcher.MatchHandlerToRoute(h, routes, matcher.Options{TopK: match.TopK})
+		missing := len(candidates) == 0 || candidates[0].Score < match.MinScore
+		ambiguous := len(candidates) > 1 && candidates[0].Score-candidates[1].Score < ambiguityMargin
+		if missing || ambiguous {
+			ambiguities = append(ambiguities, Ambiguity{Handler: h, Candidates: candidates})
+		}
+	}
+	return ambiguities
+}
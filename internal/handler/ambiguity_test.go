@@ -0,0 +1,33 @@
+package handler
+
+import (
+	"testing"
+
+	"github.com/insectkorea/swagGPT/internal/matcher"
+	"github.com/insectkorea/swagGPT/internal/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAmbiguities(t *testing.T) {
+	routes := []model.Route{
+		{Method: "GET", Path: "/users", Pattern: "/users"},
+		{Method: "GET", Path: "/admin/users", Pattern: "/admin/users"},
+		{Method: "GET", Path: "/orgs", Pattern: "/orgs"},
+		{Method: "GET", Path: "/health", Pattern: "/health", Handler: "Health"},
+	}
+	listUsers := matcher.Handler{Name: "ListUsers"}
+	listOrgs := matcher.Handler{Name: "ListOrgs"}
+	health := matcher.Handler{Name: "Health"}
+	ping := matcher.Handler{Name: "Ping"}
+	version := matcher.Handler{Name: "Version"}
+
+	ambiguities := Ambiguities([]matcher.Handler{listUsers, listOrgs, health, ping, version}, routes,
+		matcher.Options{TopK: 3, MinScore: 0.3, Unrouted: []matcher.Handler{version}})
+
+	if assert.Len(t, ambiguities, 2) {
+		assert.Equal(t, listUsers, ambiguities[0].Handler)
+		assert.Equal(t, []model.Route{routes[0], routes[1]}, matcher.CandidateRoutes(ambiguities[0].Candidates))
+		assert.Equal(t, ping, ambiguities[1].Handler)
+		assert.Empty(t, ambiguities[1].Candidates)
+	}
+}
@@ -0,0 +1,199 @@
+package handler
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/insectkorea/swagGPT/internal/matcher"
+	"github.com/insectkorea/swagGPT/internal/model"
+)
+
+// mergeAnnotations adds the @Security and @Failure annotations the middleware
+// of routes declares to comment, the Swagger comment generated for their
+// handler. They replace the generated annotations for the same scheme or
+// status code. Security lines go before the parameters and responses, and
+// failure lines after the other responses, in the order of routes. Pinned
+// tags replace the generated @Tags lines.
+func mergeAnnotations(comment string, routes []model.Route) string {
+	var tags, security, failures []string
+	codes := map[string]bool{}
+	for _, route := range routes {
+		for _, tag := range route.Tags {
+			if !slices.Contains(tags, tag) {
+				tags = append(tags, tag)
+			}
+		}
+		for _, scheme := range route.Security {
+			if !slices.Contains(security, scheme) {
+				security = append(security, scheme)
+			}
+		}
+		for _, failure := range route.Failures {
+			if code, _, _ := strings.Cut(strings.TrimSpace(failure), " "); !codes[code] {
+				codes[code] = true
+				failures = append(failures, failure)
+			}
+		}
+	}
+	if len(tags) == 0 && len(security) == 0 && len(failures) == 0 {
+		return comment
+	}
+
+	// Tag lines replace the first generated one, or else go before security
+	tagsAt := -1
+	var lines []string
+	for _, line := range commentLines(comment) {
+		tag, args := annotation(line)
+		if tag == "@Tags" && len(tags) > 0 {
+			if tagsAt < 0 {
+				tagsAt = len(lines)
+			}
+			continue
+		}
+		if len(args) > 0 && ((tag == "@Security" && slices.Contains(security, args[0])) || (tag == "@Failure" && codes[args[0]])) {
+			continue
+		}
+		lines = append(lines, line)
+	}
+
+	// Security lines go before the first parameter, response or @Router line,
+	// and failure lines after the last response or else before @Router
+	securityAt, failureAt, routerAt := len(lines), -1, len(lines)
+	for i, line := range lines {
+		switch tag, _ := annotation(line); tag {
+		case "@Param":
+			securityAt = min(securityAt, i)
+		case "@Success", "@Failure", "@Response":
+			securityAt = min(securityAt, i)
+			failureAt = i + 1
+		case "@Router":
+			securityAt = min(securityAt, i)
+			routerAt = min(routerAt, i)
+		}
+	}
+	if failureAt < 0 {
+		failureAt = routerAt
+	}
+	if tagsAt < 0 {
+		tagsAt = securityAt
+	}
+
+	var merged []string
+	for i := 0; i <= len(lines); i++ {
+		if i == tagsAt && len(tags) > 0 {
+			merged = append(merged, "// @Tags "+strings.Join(tags, ","))
+		}
+		if i == securityAt {
+			for _, scheme := range security {
+				merged = append(merged, "// @Security "+scheme)
+			}
+		}
+		if i == failureAt {
+			for _, failure := range failures {
+				merged = append(merged, "// @Failure "+failure)
+			}
+		}
+		if i < len(lines) {
+			merged = append(merged, lines[i])
+		}
+	}
+	return strings.Join(merged, "\n") + "\n"
+}
+
+// documentRoutes sets the @Router lines of comment, the Swagger comment
+// generated for a handler registered with routes, to the paths of routes, or
+// rewrites the generated paths in the syntax of @Router paths if no route is
+// bound to the handler. It then adds a @Param line for each path parameter
+// the comment does not document.
+func documentRoutes(comment string, routes []model.Route) string {
+	var routers []string
+	for _, route := range routes {
+		line := fmt.Sprintf("// @Router %s [%s]", matcher.RouterPath(route), strings.ToLower(route.Method))
+		if !slices.Contains(routers, line) {
+			routers = append(routers, line)
+		}
+	}
+
+	var lines []string
+	routerAt := -1
+	for _, line := range commentLines(comment) {
+		tag, args := annotation(line)
+		switch {
+		case tag != "@Router" || len(args) == 0:
+			lines = append(lines, line)
+		case len(routers) > 0:
+			if routerAt < 0 {
+				routerAt = len(lines)
+			}
+		default:
+			lines = append(lines, strings.Replace(line, args[0], matcher.SwaggerPath(args[0], ""), 1))
+		}
+	}
+	if len(routers) > 0 {
+		if routerAt < 0 {
+			routerAt = len(lines)
+		}
+		lines = slices.Insert(lines, routerAt, routers...)
+	}
+
+	return strings.Join(addPathParams(lines), "\n") + "\n"
+}
+
+// addPathParams adds a @Param line for each parameter of the @Router paths in
+// lines that has no @Param line of the path kind, after the other parameters
+// or else before the responses.
+func addPathParams(lines []string) []string {
+	documented := map[string]bool{}
+	var missing []string
+	paramAt, responseAt := -1, len(lines)
+	for i, line := range lines {
+		tag, args := annotation(line)
+		switch tag {
+		case "@Param":
+			if len(args) > 1 && args[1] == "path" {
+				documented[args[0]] = true
+			}
+			paramAt = i + 1
+		case "@Success", "@Failure", "@Response", "@Router":
+			responseAt = min(responseAt, i)
+		}
+		if tag == "@Router" && len(args) > 0 {
+			for _, name := range matcher.PathParams(args[0]) {
+				if !slices.Contains(missing, name) {
+					missing = append(missing, name)
+				}
+			}
+		}
+	}
+	missing = slices.DeleteFunc(missing, func(name string) bool {
+		return documented[name]
+	})
+	if len(missing) == 0 {
+		return lines
+	}
+
+	if paramAt < 0 {
+		paramAt = responseAt
+	}
+	var params []string
+	for _, name := range missing {
+		params = append(params, fmt.Sprintf("// @Param %s path string true %q", name, name))
+	}
+	return slices.Insert(lines, paramAt, params...)
+}
+
+// commentLines splits comment into its lines.
+func commentLines(comment string) []string {
+	return strings.Split(strings.TrimSuffix(comment, "\n"), "\n")
+}
+
+// annotation returns the tag of the Swagger annotation on a comment line, as in
+// "@Failure", and its arguments.
+func annotation(line string) (tag string, args []string) {
+	fields := strings.Fields(strings.TrimPrefix(strings.TrimSpace(line), "//"))
+	if len(fields) == 0 || !strings.HasPrefix(fields[0], "@") {
+		return "", nil
+	}
+	return fields[0], fields[1:]
+}
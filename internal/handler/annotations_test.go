@@ -0,0 +1,131 @@
+package handler
+
+import (
+	"testing"
+
+	"github.com/insectkorea/swagGPT/internal/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMergeAnnotations(t *testing.T) {
+	comment := `// GetUser godoc
+// @Summary Get a user
+// @Param id path int true "User ID"
+// @Success 200 {object} User
+// @Failure 401 {string} string "unauthorized"
+// @Failure 404 {object} api.Error "Not Found"
+// @Router /users/{id} [get]
+`
+	routes := []model.Route{
+		{Security: []string{"BearerAuth"}, Failures: []string{`401 {object} api.Error "Unauthorized"`}},
+		{Security: []string{"BearerAuth", "ApiKeyAuth"}, Failures: []string{`401 {string} string "Unauthorized"`, `403 {object} api.Error "Forbidden"`}},
+	}
+
+	assert.Equal(t, `// GetUser godoc
+// @Summary Get a user
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Param id path int true "User ID"
+// @Success 200 {object} User
+// @Failure 404 {object} api.Error "Not Found"
+// @Failure 401 {object} api.Error "Unauthorized"
+// @Failure 403 {object} api.Error "Forbidden"
+// @Router /users/{id} [get]
+`, mergeAnnotations(comment, routes))
+}
+
+func TestMergeAnnotationsWithoutResponses(t *testing.T) {
+	comment := `// Ping godoc
+// @Summary Ping
+// @Router /ping [get]`
+	routes := []model.Route{{Failures: []string{`401 {object} api.Error "Unauthorized"`}}}
+
+	assert.Equal(t, `// Ping godoc
+// @Summary Ping
+// @Failure 401 {object} api.Error "Unauthorized"
+// @Router /ping [get]
+`, mergeAnnotations(comment, routes))
+	assert.Equal(t, comment, mergeAnnotations(comment, []model.Route{{}}))
+}
+
+func TestMergeAnnotationsTags(t *testing.T) {
+	comment := `// ListUsers godoc
+// @Summary List users
+// @Tags Users
+// @Tags admin
+// @Produce json
+// @Success 200 {array} User
+// @Router /users [get]
+`
+	routes := []model.Route{{Tags: []string{"users", "accounts"}, Security: []string{"BearerAuth"}}}
+
+	assert.Equal(t, `// ListUsers godoc
+// @Summary List users
+// @Tags users,accounts
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} User
+// @Router /users [get]
+`, mergeAnnotations(comment, routes))
+
+	assert.Equal(t, `// Ping godoc
+// @Tags health
+// @Router /ping [get]
+`, mergeAnnotations("// Ping godoc\n// @Router /ping [get]\n", []model.Route{{Tags: []string{"health"}}}))
+}
+
+func TestDocumentRoutes(t *testing.T) {
+	comment := `// GetOrder godoc
+// @Summary Get an order
+// @Param orderID path int true "Order ID"
+// @Param expand query string false "Fields to expand"
+// @Success 200 {object} Order
+// @Router /users/:userID/orders/:orderID [get]
+`
+	routes := []model.Route{
+		{Method: "GET", Pattern: "/api/users/:userID/orders/:id", RouterPath: "/users/{userID}/orders/{id}"},
+		{Method: "HEAD", Pattern: "/api/users/:userID/orders/:id", RouterPath: "/users/{userID}/orders/{id}"},
+	}
+
+	assert.Equal(t, `// GetOrder godoc
+// @Summary Get an order
+// @Param orderID path int true "Order ID"
+// @Param expand query string false "Fields to expand"
+// @Param userID path string true "userID"
+// @Param id path string true "id"
+// @Success 200 {object} Order
+// @Router /users/{userID}/orders/{id} [get]
+// @Router /users/{userID}/orders/{id} [head]
+`, documentRoutes(comment, routes))
+}
+
+func TestDocumentRoutesWithoutBinding(t *testing.T) {
+	comment := `// GetFile godoc
+// @Summary Get a file
+// @Success 200 {file} file
+// @Router /files/*filepath [get]
+`
+
+	assert.Equal(t, `// GetFile godoc
+// @Summary Get a file
+// @Param filepath path string true "filepath"
+// @Success 200 {file} file
+// @Router /files/{filepath} [get]
+`, documentRoutes(comment, nil))
+}
+
+func TestDocumentRoutesPlaceholders(t *testing.T) {
+	comment := `// GetFile godoc
+// @Summary Get a file of an item
+// @Success 200 {file} file
+// @Router /items/{id:[0-9]+}/files/{path...} [get]
+`
+
+	assert.Equal(t, `// GetFile godoc
+// @Summary Get a file of an item
+// @Param id path string true "id"
+// @Param path path string true "path"
+// @Success 200 {file} file
+// @Router /items/{id}/files/{path} [get]
+`, documentRoutes(comment, nil))
+}
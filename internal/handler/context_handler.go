@@ -5,11 +5,15 @@ import (
 	"go/ast"
 	"go/parser"
 	"go/token"
-	"log"
+	"go/types"
 	"os"
+	"slices"
 	"strings"
 
+	"github.com/insectkorea/swagGPT/framework"
 	"github.com/insectkorea/swagGPT/internal/model"
+	"github.com/insectkorea/swagGPT/internal/scanner"
+	"github.com/sirupsen/logrus"
 )
 
 type ContextFileHandler struct {
@@ -31,99 +35,792 @@ func (ch *ContextFileHandler) ExtractRoutes(contextFilePath string) ([]model.Rou
 	// Parse the file
 	node, err := parser.ParseFile(fset, contextFilePath, nil, parser.ParseComments)
 	if err != nil {
-		log.Fatalf("Failed to parse file: %v", err)
+		return nil, fmt.Errorf("failed to parse file %s: %v", contextFilePath, err)
 	}
 
 	// Initialize the RouteExtractor
 	extractor := &RouteExtractor{
-		Routes:     []model.Route{},
-		groupStack: []string{},
+		Routes: []model.Route{},
 	}
 
 	// Traverse the AST with the extractor
-	ast.Walk(extractor, node)
+	extractor.Extract(Source{File: node, Info: scanner.CheckFile(fset, node), Fset: fset})
+	for _, warning := range extractor.Warnings {
+		logrus.Warn(warning)
+	}
 
 	return extractor.Routes, nil
 }
 
+// Source is a file routes are extracted from, along with the type
+// information of its package.
+type Source struct {
+	File *ast.File
+	Info *types.Info
+	// Fset positions the warnings about the file.
+	Fset *token.FileSet
+	// Package is the import path of the file's package, or empty if it is
+	// unknown, as when the file is type checked on its own.
+	Package string
+}
+
 type RouteExtractor struct {
-	Routes     []model.Route
-	groupStack []string
+	Routes []model.Route
+	// Warnings report the routes and prefixes whose path cannot be determined
+	// statically.
+	Warnings []string
+
+	// funcs indexes the functions declared in the extracted files by full
+	// name, as in "example.com/app/routes.RegisterUsers".
+	funcs map[string]funcSource
+	// walked holds the functions already walked with a given set of argument
+	// prefixes, so that helpers called from many places are walked once.
+	walked  map[string]bool
+	visited map[*ast.FuncDecl]bool
+	walking map[*ast.FuncDecl]bool
+	warned  map[string]bool
+	// chained holds the calls that are part of a route chain already recorded
+	// from its outermost call, as in gorilla/mux's
+	// r.HandleFunc(path, h).Methods("GET").
+	chained map[*ast.CallExpr]bool
+	// groups maps the variables holding a router or router group, as in
+	// api := app.Group("/api"), to the group's full prefix and middleware.
+	groups map[variable]group
+	// registered maps the router variables to the indices in Routes of the
+	// routes registered on them, to prefix them when the router is mounted
+	// afterwards, as in chi's r.Mount("/admin", admin).
+	registered map[variable][]int
+	// receivers maps variables to the name of their type, as in
+	// h := &UserHandler{}, to bind routes registered with h.GetUser to the
+	// methods of UserHandler.
+	receivers map[variable]string
+	// tables maps the variables holding a slice, array or map literal to it,
+	// for the routes registered in a loop over its entries.
+	tables map[variable]*ast.CompositeLit
+	// entries maps the variables of the loops being walked to the table
+	// entry of the current iteration, as in rt of
+	// for _, rt := range routes { r.Handle(rt.Method, rt.Path, rt.H) }.
+	entries map[variable]*ast.CompositeLit
+}
+
+// source is a file being extracted.
+type source struct {
+	file *ast.File
+	info *types.Info
+	fset *token.FileSet
+	pkg  string
+	// frameworks are the frameworks whose router calls are recognized.
+	frameworks []framework.Framework
+}
+
+// funcSource is a function declared in a file being extracted.
+type funcSource struct {
+	decl *ast.FuncDecl
+	src  *source
+}
+
+// variable identifies a variable by the object it denotes, or by name if the
+// file could not be type checked.
+type variable struct {
+	obj  types.Object
+	name string
+}
+
+// Extract collects the routes registered in sources. Info resolves the
+// identifiers of each file to the variables and functions they denote, so
+// that each router call is tied to the group assigned to that very variable,
+// and routers passed to functions, as in RegisterUsers(api.Group("/users")),
+// carry their prefix into the function, even across packages.
+func (re *RouteExtractor) Extract(sources ...Source) {
+	re.funcs = map[string]funcSource{}
+	re.walked = map[string]bool{}
+	re.visited = map[*ast.FuncDecl]bool{}
+	re.walking = map[*ast.FuncDecl]bool{}
+	re.warned = map[string]bool{}
+	re.chained = map[*ast.CallExpr]bool{}
+	re.groups = map[variable]group{}
+	re.registered = map[variable][]int{}
+	re.receivers = map[variable]string{}
+	re.tables = map[variable]*ast.CompositeLit{}
+	re.entries = map[variable]*ast.CompositeLit{}
+
+	var srcs []*source
+	for _, s := range sources {
+		src := &source{file: s.File, info: s.Info, fset: s.Fset, pkg: s.Package, frameworks: framework.Imported(s.File)}
+		srcs = append(srcs, src)
+		re.collectTables(src)
+		for _, decl := range s.File.Decls {
+			if fn, ok := decl.(*ast.FuncDecl); ok {
+				if key := src.funcKey(fn.Name); key != "" {
+					re.funcs[key] = funcSource{decl: fn, src: src}
+				}
+			}
+		}
+	}
+
+	// Functions called from the sources are walked from their call sites, with
+	// the routers passed to them. Those whose callers are never walked, such as
+	// recursive ones, are walked on their own last.
+	called := re.calledFuncs(srcs)
+	for _, src := range srcs {
+		for _, decl := range src.file.Decls {
+			if fn, ok := decl.(*ast.FuncDecl); ok && called[src.funcKey(fn.Name)] {
+				continue
+			}
+			ast.Walk(&routeVisitor{extractor: re, src: src}, decl)
+		}
+	}
+	for _, src := range srcs {
+		for _, decl := range src.file.Decls {
+			if fn, ok := decl.(*ast.FuncDecl); ok && called[src.funcKey(fn.Name)] && !re.visited[fn] {
+				(&routeVisitor{extractor: re, src: src}).walkCall(funcSource{decl: fn, src: src}, nil)
+			}
+		}
+	}
+}
+
+// collectTables records the slice, array and map literals assigned to
+// variables in src, wherever the loops over them appear.
+func (re *RouteExtractor) collectTables(src *source) {
+	assign := func(lhs ast.Expr, rhs ast.Expr) {
+		ident, ok := lhs.(*ast.Ident)
+		if !ok || ident.Name == "_" {
+			return
+		}
+		if lit, ok := rhs.(*ast.CompositeLit); ok && isTable(src.info, lit) {
+			re.tables[src.variable(ident)] = lit
+		}
+	}
+	ast.Inspect(src.file, func(node ast.Node) bool {
+		switch x := node.(type) {
+		case *ast.AssignStmt:
+			if len(x.Lhs) == len(x.Rhs) {
+				for i, lhs := range x.Lhs {
+					assign(lhs, x.Rhs[i])
+				}
+			}
+		case *ast.ValueSpec:
+			if len(x.Names) == len(x.Values) {
+				for i, name := range x.Names {
+					assign(name, x.Values[i])
+				}
+			}
+		}
+		return true
+	})
+}
+
+// isTable reports whether lit is a slice, array or map literal.
+func isTable(info *types.Info, lit *ast.CompositeLit) bool {
+	if info != nil {
+		if t := info.TypeOf(lit); t != nil {
+			switch t.Underlying().(type) {
+			case *types.Slice, *types.Array, *types.Map:
+				return true
+			}
+		}
+	}
+	switch lit.Type.(type) {
+	case *ast.ArrayType, *ast.MapType:
+		return true
+	}
+	return false
+}
+
+// calledFuncs returns the full names of the functions called in srcs, or
+// passed to a router scope, as in chi's r.Route("/users", userRoutes).
+func (re *RouteExtractor) calledFuncs(srcs []*source) map[string]bool {
+	called := map[string]bool{}
+	for _, src := range srcs {
+		ast.Inspect(src.file, func(node ast.Node) bool {
+			call, ok := node.(*ast.CallExpr)
+			if !ok {
+				return true
+			}
+			called[src.funcKey(call.Fun)] = true
+			if c, ok := src.routerCall(call); ok && c.Kind == framework.Scope {
+				for _, arg := range call.Args {
+					called[src.funcKey(arg)] = true
+				}
+			}
+			return true
+		})
+	}
+	delete(called, "")
+	return called
+}
+
+// group is the path prefix and the middleware of the routes registered on a
+// router.
+type group struct {
+	prefix     string
+	middleware []string
 }
 
-func (re *RouteExtractor) Visit(node ast.Node) ast.Visitor {
+// sub returns the group of the routes registered under path on g, guarded by
+// middleware as well.
+func (g group) sub(path string, middleware []string) group {
+	return group{prefix: g.prefix + path, middleware: slices.Concat(g.middleware, middleware)}
+}
+
+// key identifies g among the groups a function is walked with.
+func (g group) key() string {
+	return g.prefix + "[" + strings.Join(g.middleware, ",") + "]"
+}
+
+// routeVisitor walks the nodes of a single router scope. Nested scopes, such
+// as chi's r.Route("/prefix", func(r chi.Router) {...}), get their own visitor
+// carrying the group.
+type routeVisitor struct {
+	extractor *RouteExtractor
+	src       *source
+	group     group
+}
+
+// position returns the position of node, if known.
+func (src *source) position(node ast.Node) string {
+	if src.fset == nil {
+		return ""
+	}
+	return src.fset.Position(node.Pos()).String()
+}
+
+// variable returns the variable ident denotes.
+func (src *source) variable(ident *ast.Ident) variable {
+	if src.info != nil {
+		if obj := src.info.ObjectOf(ident); obj != nil {
+			return variable{obj: obj}
+		}
+	}
+	return variable{name: ident.Name}
+}
+
+// funcKey returns the full name of the function or method expr refers to, as
+// in "example.com/app/routes.RegisterUsers", or an empty string.
+func (src *source) funcKey(expr ast.Expr) string {
+	var ident *ast.Ident
+	switch x := expr.(type) {
+	case *ast.Ident:
+		ident = x
+	case *ast.SelectorExpr:
+		ident = x.Sel
+	case *ast.ParenExpr:
+		return src.funcKey(x.X)
+	default:
+		return ""
+	}
+	if src.info == nil {
+		return ""
+	}
+	if fn, ok := src.info.ObjectOf(ident).(*types.Func); ok {
+		return fn.Origin().FullName()
+	}
+	return ""
+}
+
+func (v *routeVisitor) Visit(node ast.Node) ast.Visitor {
 	switch x := node.(type) {
+	case *ast.AssignStmt:
+		if len(x.Lhs) == len(x.Rhs) {
+			for i, lhs := range x.Lhs {
+				v.assignGroup(lhs, x.Rhs[i])
+				v.assignReceiver(lhs, x.Rhs[i])
+			}
+		}
+		return v
+	case *ast.ValueSpec:
+		if len(x.Names) == len(x.Values) {
+			for i, name := range x.Names {
+				v.assignGroup(name, x.Values[i])
+				v.assignReceiver(name, x.Values[i])
+			}
+		} else if len(x.Values) == 0 && x.Type != nil {
+			for _, name := range x.Names {
+				v.extractor.receivers[v.src.variable(name)] = typeName(x.Type)
+			}
+		}
+		return v
+	case *ast.FuncDecl:
+		v.declareReceivers(x.Recv)
+		v.declareReceivers(x.Type.Params)
+	case *ast.FuncLit:
+		v.declareReceivers(x.Type.Params)
+	case *ast.RangeStmt:
+		return v.visitRange(x)
 	case *ast.CallExpr:
-		selExpr, ok := x.Fun.(*ast.SelectorExpr)
-		if !ok {
-			return re
+		return v.visitCall(x)
+	}
+	return v
+}
+
+// declareReceivers records the types of the parameters in fields, as in
+// func registerUsers(r *gin.Engine, h *UserHandler).
+func (v *routeVisitor) declareReceivers(fields *ast.FieldList) {
+	if fields == nil {
+		return
+	}
+	for _, field := range fields.List {
+		for _, name := range field.Names {
+			v.extractor.receivers[v.src.variable(name)] = typeName(field.Type)
+		}
+	}
+}
+
+// visitRange walks the body of a loop over a table literal once per entry,
+// with the loop variable bound to the entry.
+func (v *routeVisitor) visitRange(x *ast.RangeStmt) ast.Visitor {
+	value, ok := x.Value.(*ast.Ident)
+	if !ok || value.Name == "_" {
+		return v
+	}
+	table, ok := ast.Unparen(x.X).(*ast.CompositeLit)
+	if ident, isIdent := ast.Unparen(x.X).(*ast.Ident); isIdent {
+		table, ok = v.extractor.tables[v.src.variable(ident)]
+	}
+	if !ok || !isTable(v.src.info, table) {
+		return v
+	}
+
+	ast.Walk(v, x.X)
+	entry := v.src.variable(value)
+	unknown := false
+	for _, elt := range table.Elts {
+		if kv, ok := elt.(*ast.KeyValueExpr); ok {
+			elt = kv.Value
+		}
+		if unary, ok := elt.(*ast.UnaryExpr); ok && unary.Op == token.AND {
+			elt = unary.X
+		}
+		if lit, ok := elt.(*ast.CompositeLit); ok {
+			v.extractor.entries[entry] = lit
+			ast.Walk(v, x.Body)
+		} else {
+			unknown = true
+		}
+	}
+	delete(v.extractor.entries, entry)
+	// Entries built otherwise, as in newRoute(...), are not known; walking
+	// the body once more reports the routes it registers with them
+	if unknown {
+		ast.Walk(v, x.Body)
+	}
+	return nil
+}
+
+func (v *routeVisitor) visitCall(x *ast.CallExpr) ast.Visitor {
+	re := v.extractor
+	if re.chained[x] {
+		return v
+	}
+	call := x
+	if len(re.entries) > 0 {
+		call = v.substitute(x).(*ast.CallExpr)
+	}
+	c, ok := v.src.routerCall(call)
+	if !ok {
+		// A call of a setup function, as in RegisterUsers(api), walks the
+		// function with the routers passed to it
+		if fn, ok := re.funcs[v.src.funcKey(x.Fun)]; ok {
+			v.walkCall(fn, x.Args)
+		}
+		return v
+	}
+
+	g := v.routerGroup(c.Router)
+	if c.Kind != framework.Registration && c.Unresolved != nil {
+		re.warn(v.src, c.Unresolved, "cannot determine the path prefix %s; routes registered under it are recorded without it", types.ExprString(c.Unresolved))
+	}
+	switch c.Kind {
+	case framework.Registration:
+		// The outer call itself is visited again when a loop body or a
+		// function is walked once more
+		for _, call := range c.Chain {
+			if call != x {
+				re.chained[call] = true
+			}
+		}
+		if c.UnresolvedMethod != nil {
+			re.warn(v.src, c.UnresolvedMethod, "cannot determine the method %s of the route; skipping it", types.ExprString(c.UnresolvedMethod))
+			break
+		}
+		if c.Unresolved != nil {
+			re.warn(v.src, c.Unresolved, "cannot determine the path %s of the route; skipping it", types.ExprString(c.Unresolved))
+			break
+		}
+		for _, route := range c.Routes {
+			r := model.Route{Method: route.Method, Host: route.Host, Path: route.Path, Params: route.Params, Position: v.src.position(x)}
+			r.Middleware = g.sub("", v.middlewareNames(route.Middleware)).middleware
+			v.bindHandler(&r, route.Handler)
+			re.addRoute(r, g.prefix)
+			if ident, ok := c.Router.(*ast.Ident); ok {
+				router := v.src.variable(ident)
+				re.registered[router] = append(re.registered[router], len(re.Routes)-1)
+			}
 		}
 
-		methodName := selExpr.Sel.Name
+	// Record r.Use(mw) on the variable r, for the routes registered on it
+	// afterwards
+	case framework.Use:
+		if ident, ok := c.Router.(*ast.Ident); ok {
+			re.groups[v.src.variable(ident)] = g.sub("", v.middlewareNames(c.Middleware))
+		}
+
+	// Visit the closure of chi's r.Route("/prefix", func(r chi.Router) {...})
+	// and the like with the prefix applied, or the function passed instead
+	case framework.Scope:
+		scope := &routeVisitor{extractor: re, src: v.src, group: g.sub(c.Path, nil)}
+		for _, arg := range call.Args {
+			if fn, ok := re.funcs[v.src.funcKey(arg)]; ok {
+				scope.walkCall(fn, nil)
+			}
+		}
+		return scope
 
-		// List of HTTP methods to look for
-		httpMethods := map[string]bool{
-			"GET":     true,
-			"POST":    true,
-			"PUT":     true,
-			"DELETE":  true,
-			"PATCH":   true,
-			"OPTIONS": true,
-			"HEAD":    true,
+	// Handle chi's r.Mount("/prefix", sub), where sub is built inline or by a
+	// function call, which is then walked under the prefix, or is a router
+	// variable, whose routes get the prefix
+	case framework.Mount:
+		mounted := g.sub(c.Path, nil)
+		if ident, ok := ast.Unparen(c.Sub).(*ast.Ident); ok {
+			sub := v.src.variable(ident)
+			for _, i := range re.registered[sub] {
+				re.Routes[i].Pattern = mounted.prefix + re.Routes[i].Pattern
+				re.Routes[i].Middleware = slices.Concat(mounted.middleware, re.Routes[i].Middleware)
+			}
+			delete(re.registered, sub)
+			re.groups[sub] = mounted.sub(re.groups[sub].prefix, re.groups[sub].middleware)
 		}
+		return &routeVisitor{extractor: re, src: v.src, group: mounted}
+	}
 
-		// Check if it's a route registration method
-		if httpMethods[methodName] {
-			// Ensure there is at least one argument for the path
-			if len(x.Args) < 1 {
-				return re
+	return v
+}
+
+// substitute returns expr with the fields of the table entries bound to loop
+// variables, as in rt.Path, replaced by their values. Expressions are copied
+// rather than modified.
+func (v *routeVisitor) substitute(expr ast.Expr) ast.Expr {
+	switch x := expr.(type) {
+	case *ast.SelectorExpr:
+		if ident, ok := x.X.(*ast.Ident); ok {
+			if entry, ok := v.extractor.entries[v.src.variable(ident)]; ok {
+				if value := v.fieldValue(entry, x.Sel.Name); value != nil {
+					return value
+				}
 			}
+		}
+	case *ast.ParenExpr:
+		y := *x
+		y.X = v.substitute(x.X)
+		return &y
+	case *ast.BinaryExpr:
+		y := *x
+		y.X, y.Y = v.substitute(x.X), v.substitute(x.Y)
+		return &y
+	case *ast.CallExpr:
+		y := *x
+		y.Args = make([]ast.Expr, len(x.Args))
+		for i, arg := range x.Args {
+			y.Args[i] = v.substitute(arg)
+		}
+		return &y
+	}
+	return expr
+}
 
-			// Extract the path argument
-			pathArg, ok := x.Args[0].(*ast.BasicLit)
-			if !ok || pathArg.Kind != token.STRING {
-				return re
+// fieldValue returns the value of the field called name in the struct literal
+// lit, or nil.
+func (v *routeVisitor) fieldValue(lit *ast.CompositeLit, name string) ast.Expr {
+	var fields *types.Struct
+	if v.src.info != nil {
+		if t := v.src.info.TypeOf(lit); t != nil {
+			fields, _ = t.Underlying().(*types.Struct)
+		}
+	}
+	for i, elt := range lit.Elts {
+		if kv, ok := elt.(*ast.KeyValueExpr); ok {
+			if key, ok := kv.Key.(*ast.Ident); ok && key.Name == name {
+				return kv.Value
 			}
+		} else if fields != nil && i < fields.NumFields() && fields.Field(i).Name() == name {
+			return elt
+		}
+	}
+	return nil
+}
 
-			// Remove quotes from the path string
-			path := strings.Trim(pathArg.Value, `"'`)
+// walkCall visits the body of fn called with args in the group of v. Each
+// parameter holds the group of the router passed to it, or that of v if there
+// is none. A function is walked once for a given set of groups.
+func (v *routeVisitor) walkCall(fn funcSource, args []ast.Expr) {
+	re := v.extractor
+	if re.walking[fn.decl] {
+		return
+	}
 
-			// Build the full path considering the group prefixes
-			fullPath := ""
-			if len(re.groupStack) > 0 {
-				fullPath = strings.Join(re.groupStack, "") + path
-			} else {
-				fullPath = path
+	key := fn.src.funcKey(fn.decl.Name) + " " + v.group.key()
+	bound := map[*ast.Ident]group{}
+	i := 0
+	for _, field := range fn.decl.Type.Params.List {
+		names := field.Names
+		if len(names) == 0 {
+			names = []*ast.Ident{nil}
+		}
+		for _, name := range names {
+			g := v.group
+			if i < len(args) {
+				g = v.routerGroup(args[i])
 			}
+			if name != nil {
+				bound[name] = g
+			}
+			key += " " + g.key()
+			i++
+		}
+	}
+	if re.walked[key] {
+		return
+	}
+	re.walked[key] = true
+	re.visited[fn.decl] = true
+
+	for name, g := range bound {
+		re.groups[fn.src.variable(name)] = g
+	}
+	re.walking[fn.decl] = true
+	defer delete(re.walking, fn.decl)
 
-			// Append the route to the slice
-			re.Routes = append(re.Routes, model.Route{
-				Method:  methodName,
-				Path:    path,
-				Pattern: fullPath,
-			})
+	ast.Walk(&routeVisitor{extractor: re, src: fn.src, group: v.group}, fn.decl)
+}
+
+// assignGroup records the group of the router group assigned to lhs, if any.
+func (v *routeVisitor) assignGroup(lhs ast.Expr, rhs ast.Expr) {
+	ident, ok := lhs.(*ast.Ident)
+	if !ok || ident.Name == "_" {
+		return
+	}
+	if call, ok := rhs.(*ast.CallExpr); ok {
+		if c, ok := v.src.routerCall(call); ok && (c.Kind == framework.Group || c.Kind == framework.Use) {
+			v.extractor.groups[v.src.variable(ident)] = v.routerGroup(call)
+			return
 		}
+	}
+	delete(v.extractor.groups, v.src.variable(ident))
+}
+
+// assignReceiver records the type of the value assigned to lhs, as given by
+// valueTypeName.
+func (v *routeVisitor) assignReceiver(lhs ast.Expr, rhs ast.Expr) {
+	ident, ok := lhs.(*ast.Ident)
+	if !ok || ident.Name == "_" {
+		return
+	}
+	if name := v.src.valueTypeName(rhs); name != "" {
+		v.extractor.receivers[v.src.variable(ident)] = name
+	} else {
+		delete(v.extractor.receivers, v.src.variable(ident))
+	}
+}
 
-		// Handle Group() calls to track prefixes
-		if selExpr.Sel.Name == "Group" && len(x.Args) >= 1 {
-			groupPathArg, ok := x.Args[0].(*ast.BasicLit)
-			if !ok || groupPathArg.Kind != token.STRING {
-				return re
+// bindHandler sets the handler of route to the function or method handler
+// refers to, once unwrapped from adapters and middleware chains. Its receiver
+// type and package are those go/types resolves, if any, and are otherwise
+// left empty unless the syntax tells them, as in h.Get where
+// h := &UserHandler{}.
+func (v *routeVisitor) bindHandler(route *model.Route, handler ast.Expr) {
+	if handler == nil {
+		return
+	}
+	expr := framework.Unwrap(v.src.file, handler)
+	// A call of a handler factory, as in ListUsers(svc)
+	if call, ok := expr.(*ast.CallExpr); ok {
+		expr = call.Fun
+	}
+
+	var name *ast.Ident
+	switch x := ast.Unparen(expr).(type) {
+	case *ast.Ident:
+		name = x
+	case *ast.SelectorExpr:
+		name = x.Sel
+	default:
+		return
+	}
+	route.Handler = name.Name
+	if v.src.info != nil {
+		if fn, ok := v.src.info.Uses[name].(*types.Func); ok {
+			fn = fn.Origin()
+			if recv := fn.Signature().Recv(); recv != nil {
+				route.HandlerReceiver = namedTypeName(recv.Type())
 			}
+			route.HandlerPackage = v.src.packagePath(fn.Pkg())
+			return
+		}
+	}
 
-			groupPath := strings.Trim(groupPathArg.Value, `"'`)
+	x, ok := ast.Unparen(expr).(*ast.SelectorExpr)
+	if !ok {
+		return
+	}
+	if recv, ok := x.X.(*ast.Ident); ok {
+		receiver := v.extractor.receivers[v.src.variable(recv)]
+		if path, ok := framework.ImportPath(v.src.file, recv.Name); ok && receiver == "" {
+			route.HandlerPackage = path
+		} else {
+			route.HandlerReceiver = receiver
+		}
+		return
+	}
+	// Methods called on a value, as in (&UserHandler{}).GetUser
+	route.HandlerReceiver = v.src.valueTypeName(x.X)
+}
+
+// packagePath returns the import path of pkg, or an empty string if it is
+// unknown. A file type checked on its own resolves no import, so the only
+// package it knows is its own, whose import path is not known.
+func (src *source) packagePath(pkg *types.Package) string {
+	if pkg == nil || src.pkg == "" {
+		return ""
+	}
+	return pkg.Path()
+}
 
-			// Push the group path to the stack
-			re.groupStack = append(re.groupStack, groupPath)
+// warn records a warning about expr in src, once.
+func (re *RouteExtractor) warn(src *source, expr ast.Expr, format string, args ...any) {
+	msg := fmt.Sprintf(format, args...)
+	if pos := src.position(expr); pos != "" {
+		msg = pos + ": " + msg
+	}
+	if !re.warned[msg] {
+		re.warned[msg] = true
+		re.Warnings = append(re.Warnings, msg)
+	}
+}
 
-			// After the group is processed, pop the stack
-			// This simplistic approach assumes no concurrent groups
-			defer func() {
-				if len(re.groupStack) > 0 {
-					re.groupStack = re.groupStack[:len(re.groupStack)-1]
-				}
-			}()
+// addRoute records route registered under prefix.
+func (re *RouteExtractor) addRoute(route model.Route, prefix string) {
+	route.Pattern = prefix + route.Path
+	re.Routes = append(re.Routes, route)
+}
+
+// routerCall classifies call with the first framework of src that recognizes
+// it.
+func (src *source) routerCall(call *ast.CallExpr) (framework.Call, bool) {
+	for _, f := range src.frameworks {
+		if c, ok := f.RouterCall(call, src.info); ok {
+			return c, true
+		}
+	}
+	return framework.Call{}, false
+}
+
+// routerGroup returns the group of the router expr evaluates to: a variable
+// holding a group, or a chain of calls such as r.Group("/v1").GET(...), chi's
+// r.With(mw).Get(...) or gorilla/mux's
+// r.PathPrefix("/api").Subrouter().HandleFunc(...).
+func (v *routeVisitor) routerGroup(expr ast.Expr) group {
+	switch x := expr.(type) {
+	case *ast.Ident:
+		if g, ok := v.extractor.groups[v.src.variable(x)]; ok {
+			return g
+		}
+	case *ast.CallExpr:
+		if c, ok := v.src.routerCall(x); ok && c.Kind == framework.Group {
+			return v.routerGroup(c.Router).sub(c.Path, v.middlewareNames(c.Middleware))
+		}
+		if c, ok := v.src.routerCall(x); ok && c.Kind == framework.Use {
+			return v.routerGroup(c.Router).sub("", v.middlewareNames(c.Middleware))
+		}
+	}
+	return v.group
+}
+
+// middlewareNames returns the names of the middleware exprs, as given by
+// middlewareName.
+func (v *routeVisitor) middlewareNames(exprs []ast.Expr) []string {
+	var names []string
+	for _, expr := range exprs {
+		if name := v.src.middlewareName(expr); name != "" {
+			names = append(names, name)
 		}
 	}
-	return re
+	return names
+}
+
+// middlewareName returns the name of the function expr refers to, or calls to
+// build the middleware as in auth.RequireJWT(), qualified by import path. A
+// method is named by its full name, as in "(*example.com/app/auth.Guard).Check".
+func (src *source) middlewareName(expr ast.Expr) string {
+	expr = ast.Unparen(expr)
+	if call, ok := expr.(*ast.CallExpr); ok {
+		expr = ast.Unparen(call.Fun)
+	}
+	if key := src.funcKey(expr); key != "" {
+		return key
+	}
+	switch x := expr.(type) {
+	case *ast.Ident:
+		return x.Name
+	case *ast.SelectorExpr:
+		if ident, ok := x.X.(*ast.Ident); ok {
+			if path, ok := framework.ImportPath(src.file, ident.Name); ok {
+				return path + "." + x.Sel.Name
+			}
+		}
+		return x.Sel.Name
+	}
+	return ""
+}
+
+// valueTypeName returns the name of the type of the value expr evaluates to,
+// as resolved by go/types, or else if it is a composite literal or new(T).
+func (src *source) valueTypeName(expr ast.Expr) string {
+	if src.info != nil {
+		if t := src.info.TypeOf(expr); t != nil {
+			if name := namedTypeName(t); name != "" {
+				return name
+			}
+		}
+	}
+	switch x := expr.(type) {
+	case *ast.ParenExpr:
+		return src.valueTypeName(x.X)
+	case *ast.UnaryExpr:
+		if x.Op == token.AND {
+			return src.valueTypeName(x.X)
+		}
+	case *ast.CompositeLit:
+		return typeName(x.Type)
+	case *ast.CallExpr:
+		if fun, ok := x.Fun.(*ast.Ident); ok && fun.Name == "new" && len(x.Args) == 1 {
+			return typeName(x.Args[0])
+		}
+	}
+	return ""
+}
+
+// namedTypeName returns the name of the named type t is, or points to.
+func namedTypeName(t types.Type) string {
+	t = types.Unalias(t)
+	if ptr, ok := t.(*types.Pointer); ok {
+		t = types.Unalias(ptr.Elem())
+	}
+	if named, ok := t.(*types.Named); ok {
+		return named.Obj().Name()
+	}
+	return ""
+}
+
+// typeName returns the name of the type expr denotes, without any pointer or
+// package qualifier.
+func typeName(expr ast.Expr) string {
+	switch x := expr.(type) {
+	case *ast.StarExpr:
+		return typeName(x.X)
+	case *ast.Ident:
+		return x.Name
+	case *ast.SelectorExpr:
+		return x.Sel.Name
+	}
+	return ""
 }
@@ -0,0 +1,701 @@
+package handler
+
+import (
+	"go/parser"
+	"go/token"
+	"testing"
+
+	"github.com/insectkorea/swagGPT/framework"
+	"github.com/insectkorea/swagGPT/internal/model"
+	"github.com/insectkorea/swagGPT/internal/scanner"
+	"github.com/stretchr/testify/assert"
+)
+
+// registrations clears the positions and middleware of routes, leaving what
+// they register. Positions depend on the layout of the test source.
+func registrations(routes []model.Route) []model.Route {
+	for i := range routes {
+		routes[i].Position = ""
+		routes[i].Middleware = nil
+	}
+	return routes
+}
+
+func TestExtractRoutesInvalidFile(t *testing.T) {
+	routeFile := createTempGoFile(t, "package main\n\nfunc main() {\n")
+
+	contextHandler := &ContextFileHandler{}
+	routes, err := contextHandler.ExtractRoutes(routeFile)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "failed to parse file "+routeFile)
+	}
+	assert.Nil(t, routes)
+
+	_, err = contextHandler.ExtractRoutes(routeFile + ".missing")
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "context file does not exist")
+	}
+}
+
+func TestExtractRoutesServeMux(t *testing.T) {
+	routeFile := createTempGoFile(t, `package main
+
+import "net/http"
+
+func main() {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /items/{id}", GetItem)
+	mux.HandleFunc("POST example.com/items/", CreateItem)
+	mux.Handle("/files/{path...}", FileServer())
+	mux.HandleFunc("/{$}", Index)
+	http.ListenAndServe(":8080", mux)
+}
+`)
+
+	contextHandler := &ContextFileHandler{}
+	routes, err := contextHandler.ExtractRoutes(routeFile)
+	assert.NoError(t, err)
+	assert.Equal(t, []model.Route{
+		{Method: "GET", Path: "/items/{id}", Pattern: "/items/{id}", Params: []string{"id"}, Handler: "GetItem"},
+		{Method: "POST", Host: "example.com", Path: "/items/", Pattern: "/items/", Handler: "CreateItem"},
+		{Method: framework.MethodAny, Path: "/files/{path}", Pattern: "/files/{path}", Params: []string{"path"}, Handler: "FileServer"},
+		{Method: framework.MethodAny, Path: "/", Pattern: "/", Handler: "Index"},
+	}, registrations(routes))
+}
+
+func TestExtractRoutesChi(t *testing.T) {
+	routeFile := createTempGoFile(t, `package main
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+func main() {
+	r := chi.NewRouter()
+	r.Use(middleware.Logger)
+	r.Get("/", Index)
+	r.With(Paginate).Get("/articles", ListArticles)
+	r.Route("/users", func(r chi.Router) {
+		r.Post("/", CreateUser)
+		r.Route("/{userID}", func(r chi.Router) {
+			r.Get("/", GetUser)
+			r.Method("PUT", "/", http.HandlerFunc(UpdateUser))
+		})
+	})
+	r.Mount("/admin", adminRouter())
+	r.Mount("/articles/comments", commentsResource{}.Routes())
+	reports := chi.NewRouter()
+	reports.Get("/daily", DailyReport)
+	r.Mount("/reports", reports)
+	reports.Get("/weekly", WeeklyReport)
+	http.ListenAndServe(":3333", r)
+}
+
+func adminRouter() http.Handler {
+	r := chi.NewRouter()
+	r.Get("/accounts", ListAccounts)
+	return r
+}
+
+type commentsResource struct{}
+
+func (rs commentsResource) Routes() chi.Router {
+	r := chi.NewRouter()
+	r.Delete("/{id}", rs.Delete)
+	return r
+}
+`)
+
+	contextHandler := &ContextFileHandler{}
+	routes, err := contextHandler.ExtractRoutes(routeFile)
+	assert.NoError(t, err)
+	assert.Equal(t, []model.Route{
+		{Method: "GET", Path: "/", Pattern: "/", Handler: "Index"},
+		{Method: "GET", Path: "/articles", Pattern: "/articles", Handler: "ListArticles"},
+		{Method: "POST", Path: "/", Pattern: "/users/", Handler: "CreateUser"},
+		{Method: "GET", Path: "/", Pattern: "/users/{userID}/", Handler: "GetUser"},
+		{Method: "PUT", Path: "/", Pattern: "/users/{userID}/", Handler: "UpdateUser"},
+		{Method: "GET", Path: "/accounts", Pattern: "/admin/accounts", Handler: "ListAccounts"},
+		{Method: "DELETE", Path: "/{id}", Pattern: "/articles/comments/{id}", Handler: "Delete", HandlerReceiver: "commentsResource"},
+		{Method: "GET", Path: "/daily", Pattern: "/reports/daily", Handler: "DailyReport"},
+		{Method: "GET", Path: "/weekly", Pattern: "/reports/weekly", Handler: "WeeklyReport"},
+	}, registrations(routes))
+}
+
+func TestExtractRoutesFiber(t *testing.T) {
+	routeFile := createTempGoFile(t, `package main
+
+import "github.com/gofiber/fiber/v2"
+
+func main() {
+	app := fiber.New()
+	app.Get("/health", Health)
+
+	api := app.Group("/api")
+	v1 := api.Group("/v1")
+	v1.Get("/users/:id", GetUser)
+	v1.Post("/users", CreateUser)
+
+	app.Route("/admin", func(admin fiber.Router) {
+		admin.Delete("/users/:id", DeleteUser)
+	})
+	app.Listen(":3000")
+}
+`)
+
+	contextHandler := &ContextFileHandler{}
+	routes, err := contextHandler.ExtractRoutes(routeFile)
+	assert.NoError(t, err)
+	assert.Equal(t, []model.Route{
+		{Method: "GET", Path: "/health", Pattern: "/health", Handler: "Health"},
+		{Method: "GET", Path: "/users/:id", Pattern: "/api/v1/users/:id", Handler: "GetUser"},
+		{Method: "POST", Path: "/users", Pattern: "/api/v1/users", Handler: "CreateUser"},
+		{Method: "DELETE", Path: "/users/:id", Pattern: "/admin/users/:id", Handler: "DeleteUser"},
+	}, registrations(routes))
+}
+
+func TestExtractRoutesGorillaMux(t *testing.T) {
+	routeFile := createTempGoFile(t, `package main
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+func main() {
+	r := mux.NewRouter()
+	r.HandleFunc("/", Index)
+	r.HandleFunc("/users/{id:[0-9]+}", GetUser).Methods("GET").Name("user")
+	r.Path("/users").Methods(http.MethodPost, http.MethodPut).HandlerFunc(SaveUser)
+	r.PathPrefix("/static/").Handler(http.FileServer(http.Dir("./static")))
+
+	api := r.PathPrefix("/api").Subrouter()
+	v1 := api.PathPrefix("/v1").Subrouter()
+	v1.HandleFunc("/orders/{id}", DeleteOrder).Methods(http.MethodDelete)
+	r.PathPrefix("/admin").Subrouter().HandleFunc("/stats", Stats).Methods("GET")
+
+	http.ListenAndServe(":8080", r)
+}
+`)
+
+	contextHandler := &ContextFileHandler{}
+	routes, err := contextHandler.ExtractRoutes(routeFile)
+	assert.NoError(t, err)
+	assert.Equal(t, []model.Route{
+		{Method: framework.MethodAny, Path: "/", Pattern: "/", Handler: "Index"},
+		{Method: "GET", Path: "/users/{id}", Pattern: "/users/{id}", Params: []string{"id"}, Handler: "GetUser"},
+		{Method: "POST", Path: "/users", Pattern: "/users", Handler: "SaveUser"},
+		{Method: "PUT", Path: "/users", Pattern: "/users", Handler: "SaveUser"},
+		{Method: framework.MethodAny, Path: "/static/", Pattern: "/static/", Handler: "FileServer", HandlerPackage: "net/http"},
+		{Method: "DELETE", Path: "/orders/{id}", Pattern: "/api/v1/orders/{id}", Params: []string{"id"}, Handler: "DeleteOrder"},
+		{Method: "GET", Path: "/stats", Pattern: "/admin/stats", Handler: "Stats"},
+	}, registrations(routes))
+}
+
+func TestExtractRoutesUnwrapsWrappers(t *testing.T) {
+	t.Cleanup(framework.Reset)
+	framework.RegisterWrapper("example.com/app/api.Wrap")
+
+	routeFile := createTempGoFile(t, `package main
+
+import (
+	"example.com/app/api"
+	"github.com/gin-gonic/gin"
+)
+
+func main() {
+	r := gin.Default()
+	r.GET("/orders/:id", api.Wrap(GetOrder))
+	r.POST("/orders", Auth(), api.Wrap(orders.Create))
+	r.Run()
+}
+`)
+
+	contextHandler := &ContextFileHandler{}
+	routes, err := contextHandler.ExtractRoutes(routeFile)
+	assert.NoError(t, err)
+	assert.Equal(t, []model.Route{
+		{Method: "GET", Path: "/orders/:id", Pattern: "/orders/:id", Handler: "GetOrder"},
+		{Method: "POST", Path: "/orders", Pattern: "/orders", Handler: "Create"},
+	}, registrations(routes))
+}
+
+func TestExtractRoutesBindsHandlers(t *testing.T) {
+	routeFile := createTempGoFile(t, `package main
+
+import (
+	"net/http"
+
+	"example.com/app/orders"
+	"github.com/gin-gonic/gin"
+)
+
+type server struct {
+	users *UserHandler
+}
+
+type UserHandler struct{}
+
+func (s *server) routes(r *gin.Engine, admin *AdminHandler) {
+	h := &UserHandler{}
+	var reports ReportHandler
+	store := orders.NewStore()
+
+	r.GET("/users/:id", Auth(), h.Get)
+	r.GET("/reports", reports.List)
+	r.GET("/admin", admin.Index)
+	r.GET("/me", s.users.Me)
+	r.GET("/health", s.health)
+	r.GET("/orders", orders.List(store))
+	r.POST("/orders", gin.WrapF(orders.Create))
+	r.GET("/static/*path", gin.WrapH(http.StripPrefix("/static", http.HandlerFunc(Static))))
+}
+`)
+
+	contextHandler := &ContextFileHandler{}
+	routes, err := contextHandler.ExtractRoutes(routeFile)
+	assert.NoError(t, err)
+	assert.Equal(t, []model.Route{
+		{Method: "GET", Path: "/users/:id", Pattern: "/users/:id", Handler: "Get", HandlerReceiver: "UserHandler"},
+		{Method: "GET", Path: "/reports", Pattern: "/reports", Handler: "List", HandlerReceiver: "ReportHandler"},
+		{Method: "GET", Path: "/admin", Pattern: "/admin", Handler: "Index", HandlerReceiver: "AdminHandler"},
+		{Method: "GET", Path: "/me", Pattern: "/me", Handler: "Me", HandlerReceiver: "UserHandler"},
+		{Method: "GET", Path: "/health", Pattern: "/health", Handler: "health", HandlerReceiver: "server"},
+		{Method: "GET", Path: "/orders", Pattern: "/orders", Handler: "List", HandlerPackage: "example.com/app/orders"},
+		{Method: "POST", Path: "/orders", Pattern: "/orders", Handler: "Create", HandlerPackage: "example.com/app/orders"},
+		{Method: "GET", Path: "/static/*path", Pattern: "/static/*path", Handler: "Static"},
+	}, registrations(routes))
+}
+
+func TestExtractRoutesGroupVariables(t *testing.T) {
+	routeFile := createTempGoFile(t, `package main
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/gofiber/fiber/v2"
+)
+
+func main() {
+	r := gin.Default()
+	v1 := r.Group("/v1")
+	users := v1.Group("/users")
+	users.GET("/:id", GetUser)
+	{
+		users := v1.Group("/accounts")
+		users.GET("/:id", GetAccount)
+	}
+	users.POST("", CreateUser)
+
+	v1 = r.Group("/v2")
+	v1.GET("/health", Health)
+	r.Run()
+}
+
+func registerUsers(users *gin.RouterGroup) {
+	users.DELETE("/:id", DeleteUser)
+}
+
+func fiberApp() {
+	app := fiber.New()
+	api := app.Group("/api")
+	api.Route("/admin", func(api fiber.Router) {
+		api.Get("/stats", Stats)
+	})
+}
+`)
+
+	contextHandler := &ContextFileHandler{}
+	routes, err := contextHandler.ExtractRoutes(routeFile)
+	assert.NoError(t, err)
+	assert.Equal(t, []model.Route{
+		{Method: "GET", Path: "/:id", Pattern: "/v1/users/:id", Handler: "GetUser"},
+		{Method: "GET", Path: "/:id", Pattern: "/v1/accounts/:id", Handler: "GetAccount"},
+		{Method: "POST", Path: "", Pattern: "/v1/users", Handler: "CreateUser"},
+		{Method: "GET", Path: "/health", Pattern: "/v2/health", Handler: "Health"},
+		{Method: "DELETE", Path: "/:id", Pattern: "/:id", Handler: "DeleteUser"},
+		{Method: "GET", Path: "/stats", Pattern: "/api/admin/stats", Handler: "Stats"},
+	}, registrations(routes))
+}
+
+func TestExtractRoutesSetupFunctions(t *testing.T) {
+	routeFile := createTempGoFile(t, `package main
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/go-chi/chi/v5"
+	"github.com/gofiber/fiber/v2"
+)
+
+func main() {
+	r := gin.Default()
+	api := r.Group("/api")
+	registerUsers(api.Group("/users"))
+	registerUsers(r.Group("/admin/users"))
+	registerUsers(r.Group("/admin/users"))
+	registerHealth(r)
+	r.Run()
+}
+
+func registerUsers(users *gin.RouterGroup) {
+	users.GET("/:id", GetUser)
+	registerPosts(users)
+}
+
+func registerPosts(g *gin.RouterGroup) {
+	g.GET("/:id/posts", ListPosts)
+}
+
+func registerHealth(r *gin.Engine) {
+	r.GET("/health", Health)
+}
+
+func chiRouter() {
+	r := chi.NewRouter()
+	r.Route("/orders", orderRoutes)
+}
+
+func orderRoutes(r chi.Router) {
+	r.Get("/", ListOrders)
+}
+
+func RequestID(c *fiber.Ctx) error {
+	return c.SendString(c.Get("X-Request-ID", "none"))
+}
+`)
+
+	contextHandler := &ContextFileHandler{}
+	routes, err := contextHandler.ExtractRoutes(routeFile)
+	assert.NoError(t, err)
+	assert.Equal(t, []model.Route{
+		{Method: "GET", Path: "/:id", Pattern: "/api/users/:id", Handler: "GetUser"},
+		{Method: "GET", Path: "/:id/posts", Pattern: "/api/users/:id/posts", Handler: "ListPosts"},
+		{Method: "GET", Path: "/:id", Pattern: "/admin/users/:id", Handler: "GetUser"},
+		{Method: "GET", Path: "/:id/posts", Pattern: "/admin/users/:id/posts", Handler: "ListPosts"},
+		{Method: "GET", Path: "/health", Pattern: "/health", Handler: "Health"},
+		{Method: "GET", Path: "/", Pattern: "/orders/", Handler: "ListOrders"},
+	}, registrations(routes))
+}
+
+func TestExtractRoutesConstantPaths(t *testing.T) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "routes.go", `package main
+
+import (
+	"net/http"
+	"path"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	PathUsers = "/users"
+	apiPrefix = "/api"
+)
+
+func setup(r *gin.Engine, base string) {
+	api := r.Group(apiPrefix + "/v1")
+	api.GET(PathUsers+"/:id", GetUser)
+	api.POST(path.Join(PathUsers, "import"), ImportUsers)
+
+	admin := r.Group(base)
+	admin.GET("/stats", Stats)
+	r.GET(base+"/health", Health)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET "+PathUsers, ListUsers)
+	r.Handle(method(), "/dynamic", Dynamic)
+	r.Handle(methodPut, PathUsers+"/:id", UpdateUser)
+}
+
+const methodPut = "put"
+
+func method() string { return "GET" }
+`, 0)
+	assert.NoError(t, err)
+
+	extractor := &RouteExtractor{}
+	extractor.Extract(Source{File: file, Info: scanner.CheckFile(fset, file), Fset: fset})
+	assert.Equal(t, []model.Route{
+		{Method: "GET", Path: "/users/:id", Pattern: "/api/v1/users/:id", Handler: "GetUser", Position: "routes.go:17:2"},
+		{Method: "POST", Path: "/users/import", Pattern: "/api/v1/users/import", Handler: "ImportUsers", Position: "routes.go:18:2"},
+		{Method: "GET", Path: "/stats", Pattern: "/stats", Handler: "Stats", Position: "routes.go:21:2"},
+		{Method: "GET", Path: "/users", Pattern: "/users", Handler: "ListUsers", Position: "routes.go:25:2"},
+		{Method: "PUT", Path: "/users/:id", Pattern: "/users/:id", Handler: "UpdateUser", Position: "routes.go:27:2"},
+	}, extractor.Routes)
+	assert.Equal(t, []string{
+		"routes.go:20:19: cannot determine the path prefix base; routes registered under it are recorded without it",
+		"routes.go:22:8: cannot determine the path base + \"/health\" of the route; skipping it",
+		"routes.go:26:11: cannot determine the method method() of the route; skipping it",
+	}, extractor.Warnings)
+}
+
+func TestExtractRoutesTables(t *testing.T) {
+	routeFile := createTempGoFile(t, `package main
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/labstack/echo/v4"
+)
+
+type route struct {
+	Method string
+	Path   string
+	H      gin.HandlerFunc
+}
+
+var userRoutes = []route{
+	{Method: http.MethodGet, Path: "/users", H: ListUsers},
+	{Method: "POST", Path: "/users", H: CreateUser},
+	{"DELETE", "/users/:id", DeleteUser},
+}
+
+func main() {
+	r := gin.Default()
+	api := r.Group("/api")
+	for _, rt := range userRoutes {
+		api.Handle(rt.Method, rt.Path, rt.H)
+	}
+
+	h := &OrderHandler{}
+	for _, rt := range []*route{
+		&route{Path: "/orders", H: h.List},
+		{Path: "/orders/:id", H: h.Get},
+	} {
+		r.GET("/v2"+rt.Path, rt.H)
+	}
+}
+
+func echoServer() {
+	e := echo.New()
+	routes := map[string]struct{ method, path string; h echo.HandlerFunc }{
+		"health": {"GET", "/health", Health},
+	}
+	for _, rt := range routes {
+		e.Add(rt.method, rt.path, rt.h)
+	}
+}
+`)
+
+	contextHandler := &ContextFileHandler{}
+	routes, err := contextHandler.ExtractRoutes(routeFile)
+	assert.NoError(t, err)
+	assert.Equal(t, []model.Route{
+		{Method: "GET", Path: "/users", Pattern: "/api/users", Handler: "ListUsers"},
+		{Method: "POST", Path: "/users", Pattern: "/api/users", Handler: "CreateUser"},
+		{Method: "DELETE", Path: "/users/:id", Pattern: "/api/users/:id", Handler: "DeleteUser"},
+		{Method: "GET", Path: "/v2/orders", Pattern: "/v2/orders", Handler: "List", HandlerReceiver: "OrderHandler"},
+		{Method: "GET", Path: "/v2/orders/:id", Pattern: "/v2/orders/:id", Handler: "Get", HandlerReceiver: "OrderHandler"},
+		{Method: "GET", Path: "/health", Pattern: "/health", Handler: "Health"},
+	}, registrations(routes))
+}
+
+func TestExtractRoutesTableUnknownEntries(t *testing.T) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "routes.go", `package main
+
+import "github.com/gin-gonic/gin"
+
+type route struct {
+	Path string
+	H    gin.HandlerFunc
+}
+
+func newRoute(path string, h gin.HandlerFunc) route {
+	return route{path, h}
+}
+
+func main() {
+	r := gin.Default()
+	health := route{"/health", Health}
+	for _, rt := range []route{
+		{Path: "/users", H: ListUsers},
+		newRoute("/orders", ListOrders),
+		health,
+	} {
+		r.GET(rt.Path, rt.H)
+	}
+}
+`, 0)
+	assert.NoError(t, err)
+
+	extractor := &RouteExtractor{}
+	extractor.Extract(Source{File: file, Info: scanner.CheckFile(fset, file), Fset: fset})
+	assert.Equal(t, []model.Route{
+		{Method: "GET", Path: "/users", Pattern: "/users", Handler: "ListUsers"},
+	}, registrations(extractor.Routes))
+	assert.Equal(t, []string{
+		"routes.go:22:9: cannot determine the path rt.Path of the route; skipping it",
+	}, extractor.Warnings)
+}
+
+func TestExtractRoutesTableUnknownEntriesHandle(t *testing.T) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "routes.go", `package main
+
+import "github.com/gin-gonic/gin"
+
+type route struct {
+	Method string
+	Path   string
+	H      gin.HandlerFunc
+}
+
+func newRoute(method, path string, h gin.HandlerFunc) route {
+	return route{method, path, h}
+}
+
+func main() {
+	r := gin.Default()
+	routes := []route{
+		{"GET", "/users/:id", GetUser},
+		newRoute("DELETE", "/users/:id", DeleteUser),
+	}
+	for _, rt := range routes {
+		r.Handle(rt.Method, rt.Path, rt.H)
+	}
+}
+`, 0)
+	assert.NoError(t, err)
+
+	extractor := &RouteExtractor{}
+	extractor.Extract(Source{File: file, Info: scanner.CheckFile(fset, file), Fset: fset})
+	assert.Equal(t, []model.Route{
+		{Method: "GET", Path: "/users/:id", Pattern: "/users/:id", Handler: "GetUser"},
+	}, registrations(extractor.Routes))
+	assert.Equal(t, []string{
+		"routes.go:22:12: cannot determine the method rt.Method of the route; skipping it",
+	}, extractor.Warnings)
+}
+
+func TestExtractRoutesMethodForms(t *testing.T) {
+	routeFile := createTempGoFile(t, `package main
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+func main() {
+	r := gin.Default()
+	r.Handle("GET", "/users", auth, ListUsers)
+	r.Any("/proxy", Proxy)
+	r.Match([]string{http.MethodGet, "post"}, "/search", Search)
+}
+`)
+	echoFile := createTempGoFile(t, `package main
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+func main() {
+	e := echo.New()
+	e.Add(http.MethodPut, "/users/:id", UpdateUser, auth)
+	e.Match([]string{"GET", "HEAD"}, "/health", Health)
+	e.Any("/echo", Echo, auth)
+}
+`)
+
+	contextHandler := &ContextFileHandler{}
+	routes, err := contextHandler.ExtractRoutes(routeFile)
+	assert.NoError(t, err)
+	echoRoutes, err := contextHandler.ExtractRoutes(echoFile)
+	assert.NoError(t, err)
+
+	var got []string
+	for _, route := range append(routes, echoRoutes...) {
+		got = append(got, route.Method+" "+route.Pattern+" "+route.Handler)
+	}
+	assert.Equal(t, []string{
+		"GET /users ListUsers",
+		"GET /proxy Proxy",
+		"POST /proxy Proxy",
+		"PUT /proxy Proxy",
+		"PATCH /proxy Proxy",
+		"DELETE /proxy Proxy",
+		"HEAD /proxy Proxy",
+		"OPTIONS /proxy Proxy",
+		"GET /search Search",
+		"POST /search Search",
+		"PUT /users/:id UpdateUser",
+		"GET /health Health",
+		"HEAD /health Health",
+		"GET /echo Echo",
+		"POST /echo Echo",
+		"PUT /echo Echo",
+		"PATCH /echo Echo",
+		"DELETE /echo Echo",
+		"HEAD /echo Echo",
+		"OPTIONS /echo Echo",
+	}, got)
+}
+
+func TestExtractRoutesMiddleware(t *testing.T) {
+	routeFile := createTempGoFile(t, `package main
+
+import (
+	"example.com/app/auth"
+	"github.com/gin-gonic/gin"
+)
+
+func main() {
+	r := gin.New()
+	r.GET("/health", Health)
+	r.Use(gin.Logger())
+	r.GET("/", Index)
+
+	api := r.Group("/api")
+	api.Use(auth.RequireJWT())
+	api.GET("/me", requireScope("profile"), Me)
+	registerAdmin(api.Group("/admin", requireAdmin))
+}
+
+func registerAdmin(r *gin.RouterGroup) {
+	r.DELETE("/users/:id", DeleteUser)
+}
+
+func requireScope(scope string) gin.HandlerFunc {
+	return nil
+}
+`)
+	echoFile := createTempGoFile(t, `package main
+
+import (
+	"example.com/app/auth"
+	"github.com/labstack/echo/v4"
+)
+
+func main() {
+	e := echo.New()
+	g := e.Group("/api", auth.RequireJWT)
+	g.GET("/items", ListItems, auth.Scope("items"))
+}
+`)
+
+	contextHandler := &ContextFileHandler{}
+	routes, err := contextHandler.ExtractRoutes(routeFile)
+	assert.NoError(t, err)
+	echoRoutes, err := contextHandler.ExtractRoutes(echoFile)
+	assert.NoError(t, err)
+
+	got := map[string][]string{}
+	for _, route := range append(routes, echoRoutes...) {
+		got[route.Method+" "+route.Pattern] = route.Middleware
+	}
+	assert.Equal(t, map[string][]string{
+		"GET /health":                 nil,
+		"GET /":                       {"github.com/gin-gonic/gin.Logger"},
+		"GET /api/me":                 {"github.com/gin-gonic/gin.Logger", "example.com/app/auth.RequireJWT", "main.requireScope"},
+		"DELETE /api/admin/users/:id": {"github.com/gin-gonic/gin.Logger", "example.com/app/auth.RequireJWT", "requireAdmin"},
+		"GET /api/items":              {"example.com/app/auth.RequireJWT", "example.com/app/auth.Scope"},
+	}, got)
+}
@@ -10,6 +10,7 @@ import (
 	"sync"
 
 	"github.com/insectkorea/swagGPT/internal/api"
+	"github.com/insectkorea/swagGPT/internal/matcher"
 	"github.com/insectkorea/swagGPT/internal/model"
 	"github.com/insectkorea/swagGPT/internal/scanner"
 
@@ -26,52 +27,44 @@ type HandlerResult struct {
 }
 
 // processFile processes a single file to add Swagger comments to its handler functions.
-func processFile(filePath string, client api.Client, dryRun bool, model string, contextFilePath string) error {
-	originalContent, handlers, fset, err := readFileAndParse(filePath)
+func processFile(file File, client api.Client, dryRun bool, model string, routes []model.Route, opts scanner.Options, match matcher.Options) error {
+	originalContent, err := os.ReadFile(file.Path)
 	if err != nil {
-		return err
-	}
-
-	contextHandler := &ContextFileHandler{}
-	routes, err := contextHandler.ExtractRoutes(contextFilePath)
-	if err != nil {
-		return err
+		return fmt.Errorf("failed to read file %s: %v", file.Path, err)
 	}
 
-	handlerResults, err := processHandlers(handlers, client, model, fset, routes)
+	handlerResults, err := processHandlers(file.selected(opts), client, model, file.Fset, routes, match)
 	if err != nil {
 		return err
 	}
 
-	return updateFileContent(filePath, originalContent, handlerResults, dryRun)
+	return updateFileContent(file.Path, originalContent, handlerResults, dryRun)
 }
 
-func readFileAndParse(filePath string) ([]byte, []*ast.FuncDecl, *token.FileSet, error) {
-	originalContent, err := os.ReadFile(filePath)
-	if err != nil {
-		return nil, nil, nil, fmt.Errorf("failed to read file %s: %v", filePath, err)
-	}
-
-	handlers, fset, err := scanner.ParseFile(filePath)
-	if err != nil {
-		return nil, nil, nil, fmt.Errorf("failed to parse file %s: %v", filePath, err)
+// referencedHandlers returns the names of the handlers routes are registered
+// with.
+func referencedHandlers(routes []model.Route) map[string]bool {
+	names := map[string]bool{}
+	for _, route := range routes {
+		if route.Handler != "" {
+			names[route.Handler] = true
+		}
 	}
-
-	return originalContent, handlers, fset, nil
+	return names
 }
 
-func processHandlers(handlers []*ast.FuncDecl, client api.Client, model string, fset *token.FileSet, routes []model.Route) ([]HandlerResult, error) {
+func processHandlers(handlers []scanner.Handler, client api.Client, model string, fset *token.FileSet, routes []model.Route, match matcher.Options) ([]HandlerResult, error) {
 	var handlerWg sync.WaitGroup
 	handlerResults := make(chan HandlerResult, len(handlers))
 
 	for _, handler := range handlers {
 		handlerWg.Add(1)
-		go func(handler *ast.FuncDecl) {
+		go func(handler scanner.Handler) {
 			defer handlerWg.Done()
-			comment, err := processHandler(handler, client, model, routes)
-			startPos := fset.Position(handler.Pos()).Offset
-			endPos := fset.Position(handler.End()).Offset
-			handlerResults <- HandlerResult{Handler: handler, Comment: comment, Error: err, StartPos: startPos, EndPos: endPos}
+			comment, err := processHandler(handler, client, model, routes, match)
+			startPos := fset.Position(handler.Decl.Pos()).Offset
+			endPos := fset.Position(handler.Decl.End()).Offset
+			handlerResults <- HandlerResult{Handler: handler.Decl, Comment: comment, Error: err, StartPos: startPos, EndPos: endPos}
 		}(handler)
 	}
 
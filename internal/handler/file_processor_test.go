@@ -9,7 +9,10 @@ import (
 	"path/filepath"
 	"testing"
 
+	"github.com/insectkorea/swagGPT/framework"
+	"github.com/insectkorea/swagGPT/internal/matcher"
 	"github.com/insectkorea/swagGPT/internal/model"
+	"github.com/insectkorea/swagGPT/internal/scanner"
 	"github.com/insectkorea/swagGPT/internal/test"
 	"github.com/stretchr/testify/assert"
 )
@@ -30,27 +33,96 @@ func TestHandler(c *gin.Context) {
 	assert.NoError(t, err)
 
 	client := &test.MockOpenAIClient{}
-	err = processFile(filePath, client, true, "test-model", "")
+	files := ParseFiles([]string{filePath}, nil, scanner.Options{})
+	assert.Len(t, files, 1)
+	err = processFile(files[0], client, true, "test-model", nil, scanner.Options{}, matcher.DefaultOptions)
 	assert.NoError(t, err)
 }
 
-func TestReadFileAndParse(t *testing.T) {
+func TestProcessFileUnexportedHandlers(t *testing.T) {
 	filePath := createTempGoFile(t, `package main
 
 import "github.com/gin-gonic/gin"
 
+type userHandler struct{}
+
+func (h *userHandler) list(c *gin.Context) {
+	c.JSON(200, []string{})
+}
+
+func (h *userHandler) render(c *gin.Context) {
+	c.String(200, "users")
+}
+`)
+	routes := []model.Route{{
+		Method: "GET", Path: "/users", Pattern: "/users", Handler: "list", HandlerReceiver: "userHandler",
+		Security: []string{"BearerAuth"}, Failures: []string{`401 {object} api.Error "Unauthorized"`},
+	}}
+
+	opts := scanner.Options{Unexported: true}
+	files := ParseFiles([]string{filePath}, routes, opts)
+	matcher.ResolveHandlers(routes, ScanHandlers(files, opts))
+
+	client := &test.MockOpenAIClient{}
+	err := processFile(files[0], client, false, "test-model", routes, opts, matcher.DefaultOptions)
+	assert.NoError(t, err)
+
+	content, err := os.ReadFile(filePath)
+	assert.NoError(t, err)
+	assert.Contains(t, string(content), "// list godoc")
+	assert.Contains(t, string(content), "// @Security BearerAuth\n// @Success 200 {string} string \"OK\"\n// @Failure 401 {object} api.Error \"Unauthorized\"\n")
+	assert.NotContains(t, string(content), "// render godoc")
+}
+
+func TestParseFiles(t *testing.T) {
+	filePath := createTempGoFile(t, `package main
+
+import "github.com/gin-gonic/gin"
+
+type UserHandler struct{}
+
 // TestHandler handles a test request
 func TestHandler(c *gin.Context) {
 	c.JSON(200, "Hello World")
 }
+
+func (h *UserHandler) list(c *gin.Context) {}
 `)
+	invalidPath := filepath.Join(t.TempDir(), "invalid.go")
+	assert.NoError(t, os.WriteFile(invalidPath, []byte("package main\n\nfunc {"), 0644))
 
-	originalContent, handlers, fset, err := readFileAndParse(filePath)
-	assert.NoError(t, err)
-	assert.NotNil(t, originalContent)
-	assert.NotNil(t, handlers)
-	assert.NotNil(t, fset)
-	assert.Equal(t, 1, len(handlers))
+	routes := []model.Route{{Method: "GET", Pattern: "/users", Handler: "list"}}
+	files := ParseFiles([]string{filePath, invalidPath}, routes, scanner.Options{Unexported: true})
+	assert.Len(t, files, 1)
+	assert.Equal(t, filePath, files[0].Path)
+	assert.NotNil(t, files[0].Fset)
+	assert.Len(t, files[0].Handlers, 2)
+
+	handlers := ScanHandlers(files, scanner.Options{Receiver: "*UserHandler"})
+	assert.Len(t, handlers, 1)
+	assert.Equal(t, "UserHandler.list", handlers[0].Receiver+"."+handlers[0].Name)
+}
+
+func TestParseFilesGenericReceiver(t *testing.T) {
+	filePath := createTempGoFile(t, `package main
+
+import "github.com/gin-gonic/gin"
+
+type Handler[T any] struct{}
+
+func (h *Handler[T]) Get(c *gin.Context) {}
+`)
+
+	routes := []model.Route{{Method: "GET", Pattern: "/items/:id", Handler: "Get", HandlerReceiver: "Handler"}}
+	opts := scanner.Options{Receiver: "*Handler"}
+	files := ParseFiles([]string{filePath}, routes, opts)
+	assert.Len(t, files, 1)
+
+	handlers := ScanHandlers(files, opts)
+	assert.Len(t, handlers, 1)
+	assert.Equal(t, "Handler", handlers[0].Receiver)
+	matcher.ResolveHandlers(routes, handlers)
+	assert.True(t, matcher.Binds(routes[0], handlers[0]))
 }
 
 func TestProcessHandlers(t *testing.T) {
@@ -71,7 +143,7 @@ func TestHandler(c *gin.Context) {
 			Method:  "GET",
 			Pattern: "/example/TestHandler",
 		},
-	})
+	}, matcher.DefaultOptions)
 	assert.NoError(t, err)
 	assert.Equal(t, 1, len(results))
 	assert.Equal(t, "// TestHandler godoc\n// @Summary TestHandler summary\n// @Description do TestHandler\n// @Success 200 {string} string \"OK\"\n// @Router /example/TestHandler [get]\n", results[0].Comment)
@@ -137,6 +209,42 @@ func TestHandler(c *gin.Context) {
 	assert.Equal(t, 120, lastPos)
 }
 
+func TestHandlerSourceOfFactory(t *testing.T) {
+	handlers := parseHandlersFromContent(t, `package main
+
+import (
+	"log"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ListUsers returns a handler listing users
+func ListUsers(svc UserService, cfg Config) gin.HandlerFunc {
+	logger := log.Default()
+	logger.Println("registering ListUsers")
+	limit := cfg.Limit
+	var page struct{ Size int }
+	page.Size = limit
+	users := svc.List()
+	return func(c *gin.Context) {
+		c.JSON(200, users[:page.Size])
+	}
+}
+`)
+
+	source, err := handlerSource(handlers[0].Decl)
+	assert.NoError(t, err)
+	assert.Equal(t, `func ListUsers(svc UserService, cfg Config) gin.HandlerFunc {
+	limit := cfg.Limit
+	var page struct{ Size int }
+	page.Size = limit
+	users := svc.List()
+	return func(c *gin.Context) {
+		c.JSON(200, users[:page.Size])
+	}
+}`, source)
+}
+
 // Helper functions
 func createTempGoFile(t *testing.T, content string) string {
 	t.Helper()
@@ -147,17 +255,52 @@ func createTempGoFile(t *testing.T, content string) string {
 	return filePath
 }
 
-func parseHandlersFromContent(t *testing.T, content string) []*ast.FuncDecl {
+func parseHandlersFromContent(t *testing.T, content string) []scanner.Handler {
 	t.Helper()
 	fset := token.NewFileSet()
 	node, err := parser.ParseFile(fset, "", content, parser.ParseComments)
 	assert.NoError(t, err)
 
-	var handlers []*ast.FuncDecl
+	gin, _ := framework.Lookup("gin")
+	var handlers []scanner.Handler
 	for _, decl := range node.Decls {
 		if fn, isFn := decl.(*ast.FuncDecl); isFn {
-			handlers = append(handlers, fn)
+			handlers = append(handlers, scanner.Handler{Decl: fn, Framework: gin})
 		}
 	}
 	return handlers
 }
+
+func TestMatchRoutesPrefersBoundRoutes(t *testing.T) {
+	handlers := parseHandlersFromContent(t, `package main
+
+func (h *UserHandler) Get(c *gin.Context) {}
+
+func ListUserOrders(c *gin.Context) {}
+`)
+	routes := []model.Route{
+		{Method: "GET", Path: "/:id", Pattern: "/users/:id", Handler: "Get", HandlerReceiver: "UserHandler"},
+		{Method: "GET", Path: "/:id", Pattern: "/orders/:id", Handler: "Get", HandlerReceiver: "OrderHandler"},
+		{Method: "GET", Path: "/users/:id/orders", Pattern: "/users/:id/orders"},
+	}
+
+	routeString, candidates := matchRoutes(handlers[0], boundRoutes(handlers[0], routes), routes, matcher.DefaultOptions)
+	assert.Equal(t, "/users/{id} [get]", routeString)
+	assert.Empty(t, candidates)
+
+	// Handlers without a binding fall back to matching by name
+	routeString, candidates = matchRoutes(handlers[1], boundRoutes(handlers[1], routes), routes, matcher.DefaultOptions)
+	assert.Equal(t, "/users/{id}/orders [get], /users/{id} [get], /orders/{id} [get]", routeString)
+	assert.Len(t, candidates, 3)
+
+	// Only the best candidates above the threshold are kept
+	routeString, _ = matchRoutes(handlers[1], boundRoutes(handlers[1], routes), routes, matcher.Options{TopK: 1})
+	assert.Equal(t, "/users/{id}/orders [get]", routeString)
+	routeString, _ = matchRoutes(handlers[1], boundRoutes(handlers[1], routes), routes, matcher.Options{MinScore: 2})
+	assert.Equal(t, "", routeString)
+
+	// Handlers registered with several routes are documented with all of them
+	routes = append(routes, model.Route{Method: "HEAD", Path: "/:id", Pattern: "/users/:id", Handler: "Get", HandlerReceiver: "UserHandler"})
+	routeString, _ = matchRoutes(handlers[0], boundRoutes(handlers[0], routes), routes, matcher.DefaultOptions)
+	assert.Equal(t, "/users/{id} [get], /users/{id} [head]\n"+boundRoutesNote, routeString)
+}
@@ -2,9 +2,14 @@ package handler
 
 import (
 	"go/ast"
+	"go/token"
+	"strings"
 	"sync"
 
 	"github.com/insectkorea/swagGPT/internal/api"
+	"github.com/insectkorea/swagGPT/internal/matcher"
+	"github.com/insectkorea/swagGPT/internal/model"
+	"github.com/insectkorea/swagGPT/internal/scanner"
 
 	"github.com/schollz/progressbar/v3"
 	"github.com/sirupsen/logrus"
@@ -16,20 +21,62 @@ type FileHandlerPair struct {
 	Handler  *ast.FuncDecl
 }
 
-// ProcessFiles processes the given files to add Swagger comments to handler functions.
-func ProcessFiles(files []string, client api.Client, dryRun bool, model string, contextFilePath string) error {
+// File is a parsed Go file and the handlers found in it.
+type File struct {
+	Path     string
+	Handlers []scanner.Handler
+	Fset     *token.FileSet
+}
+
+// ParseFiles parses each of files once, keeping the handlers selected by opts,
+// with the unexported ones routes are registered with if opts.Unexported is
+// set. Files that fail to parse are logged and skipped.
+func ParseFiles(files []string, routes []model.Route, opts scanner.Options) []File {
+	opts.Referenced = referencedHandlers(routes)
+
+	var parsed []File
+	for _, file := range files {
+		handlers, fset, err := scanner.ParseFile(file, &opts)
+		if err != nil {
+			logrus.Errorf("Error parsing file %s: %v", file, err)
+			continue
+		}
+		parsed = append(parsed, File{Path: file, Handlers: handlers, Fset: fset})
+	}
+	return parsed
+}
+
+// selected returns the handlers of f that are methods of opts.Receiver, or all
+// of them if it is not set.
+func (f File) selected(opts scanner.Options) []scanner.Handler {
+	if opts.Receiver == "" {
+		return f.Handlers
+	}
+	var handlers []scanner.Handler
+	for _, handler := range f.Handlers {
+		if scanner.ReceiverTypeName(handler.Decl) == strings.TrimPrefix(opts.Receiver, "*") {
+			handlers = append(handlers, handler)
+		}
+	}
+	return handlers
+}
+
+// ProcessFiles processes the given files to add Swagger comments to their
+// handler functions selected by opts, documenting them with the routes they
+// are bound to or the candidate routes selected by match.
+func ProcessFiles(files []File, client api.Client, dryRun bool, model string, routes []model.Route, opts scanner.Options, match matcher.Options) error {
 	bar := progressbar.Default(int64(len(files)))
 
 	var wg sync.WaitGroup
 
 	for _, file := range files {
 		wg.Add(1)
-		go func(filename string) {
+		go func(file File) {
 			defer wg.Done()
 			// nolint:errcheck
 			defer bar.Add(1)
-			if err := processFile(filename, client, dryRun, model, contextFilePath); err != nil {
-				logrus.Errorf("Error processing file %s: %v", filename, err)
+			if err := processFile(file, client, dryRun, model, routes, opts, match); err != nil {
+				logrus.Errorf("Error processing file %s: %v", file.Path, err)
 			}
 		}(file)
 	}
@@ -6,29 +6,173 @@ import (
 	"go/ast"
 	"go/format"
 	"go/token"
+	"slices"
+	"strings"
 
 	"github.com/insectkorea/swagGPT/internal/api"
 	"github.com/insectkorea/swagGPT/internal/matcher"
 	"github.com/insectkorea/swagGPT/internal/model"
+	"github.com/insectkorea/swagGPT/internal/scanner"
+	"github.com/sirupsen/logrus"
 )
 
 // processHandler processes a single handler to generate a Swagger comment.
-func processHandler(handler *ast.FuncDecl, client api.Client, model string, routes []model.Route) (string, error) {
+func processHandler(handler scanner.Handler, client api.Client, model string, routes []model.Route, match matcher.Options) (string, error) {
+	fn := handler.Decl
+	handlerContent, err := handlerSource(fn)
+	if err != nil {
+		return "", err
+	}
+
+	bound := boundRoutes(handler, routes)
+	routeString, candidates := matchRoutes(handler, bound, routes, match)
+	for _, candidate := range candidates {
+		logrus.Infof("Handler %s: candidate route %s [%s] scored %.2f: %s", fn.Name.Name,
+			matcher.RouterPath(candidate.Route), strings.ToLower(candidate.Route.Method), candidate.Score, strings.Join(candidate.Reasons, ", "))
+	}
+
+	comment, err := client.GenerateSwaggerComment(fn.Name.Name, handlerContent, model, routeString, promptHints(handler))
+	if err != nil {
+		return "", fmt.Errorf("failed to generate comment for %s: %v", fn.Name.Name, err)
+	}
+
+	return documentRoutes(mergeAnnotations(comment, bound), bound), nil
+}
+
+// boundRoutesNote follows the routes of a handler registered with several
+// routes, such as with r.Any(path, h), which are all documented.
+const boundRoutesNote = "The handler serves all of these routes. Add a @Router line for each of them."
+
+// matchRoutes returns the route string of bound, the routes handler is
+// registered with, or else of the candidate routes selected by match among
+// those guessed from its name, along with these candidates.
+func matchRoutes(handler scanner.Handler, bound []model.Route, routes []model.Route, match matcher.Options) (string, []matcher.Candidate) {
+	if len(bound) > 1 {
+		return matcher.FormatRoutes(bound) + "\n" + boundRoutesNote, nil
+	}
+	if len(bound) > 0 {
+		return matcher.FormatRoutes(bound), nil
+	}
+	candidates := matcher.MatchHandlerToRoute(matcherHandler(handler), routes, match)
+	return matcher.FormatRoutes(matcher.CandidateRoutes(candidates)), candidates
+}
+
+// boundRoutes returns the routes handler is registered with.
+func boundRoutes(handler scanner.Handler, routes []model.Route) []model.Route {
+	return matcher.BoundRoutes(matcherHandler(handler), routes)
+}
+
+// matcherHandler identifies handler to the matcher.
+func matcherHandler(handler scanner.Handler) matcher.Handler {
+	return matcher.Handler{
+		Name:     handler.Decl.Name.Name,
+		Receiver: scanner.ReceiverTypeName(handler.Decl),
+		Package:  handler.Package,
+	}
+}
+
+// handlerSource returns the source of the handler that is sent to the model.
+// For a handler factory only the outer signature, the returned closure and the
+// declarations it depends on are kept, since the rest of the setup code says
+// nothing about the endpoint.
+func handlerSource(handler *ast.FuncDecl) (string, error) {
+	node := handler
+	if closure := scanner.HandlerClosure(handler); closure != nil {
+		ret := &ast.ReturnStmt{Results: []ast.Expr{closure}}
+		node = &ast.FuncDecl{
+			Recv: handler.Recv,
+			Name: handler.Name,
+			Type: handler.Type,
+			Body: &ast.BlockStmt{List: append(closureDecls(handler.Body.List, closure), ret)},
+		}
+	}
+
 	var buf bytes.Buffer
-	if err := format.Node(&buf, token.NewFileSet(), handler); err != nil {
+	if err := format.Node(&buf, token.NewFileSet(), node); err != nil {
 		return "", fmt.Errorf("failed to format handler %s: %v", handler.Name.Name, err)
 	}
-	handlerContent := buf.String()
+	return buf.String(), nil
+}
 
-	routeString, err := matcher.MatchHandlerToRoute(handler.Name.Name, routes)
-	if err != nil {
-		return "", fmt.Errorf("failed to match handler to route for %s: %v", handler.Name.Name, err)
+// closureDecls returns the statements of stmts, the body of a handler factory,
+// that declare or assign the variables, constants and types closure uses,
+// directly or through the other statements kept.
+func closureDecls(stmts []ast.Stmt, closure *ast.FuncLit) []ast.Stmt {
+	used := map[string]bool{}
+	addUses := func(node ast.Node) {
+		ast.Inspect(node, func(n ast.Node) bool {
+			if ident, ok := n.(*ast.Ident); ok {
+				used[ident.Name] = true
+			}
+			return true
+		})
 	}
+	addUses(closure)
 
-	comment, err := client.GenerateSwaggerComment(handler.Name.Name, handlerContent, model, routeString)
-	if err != nil {
-		return "", fmt.Errorf("failed to generate comment for %s: %v", handler.Name.Name, err)
+	var kept []ast.Stmt
+	for i := len(stmts) - 1; i >= 0; i-- {
+		if slices.ContainsFunc(declaredNames(stmts[i]), func(name string) bool { return used[name] }) {
+			kept = append(kept, stmts[i])
+			addUses(stmts[i])
+		}
 	}
+	slices.Reverse(kept)
+	return kept
+}
+
+// declaredNames returns the names stmt declares or assigns to, including the
+// variables whose fields or elements it sets, as page in page.Size = 10.
+func declaredNames(stmt ast.Stmt) []string {
+	var names []string
+	switch s := stmt.(type) {
+	case *ast.AssignStmt:
+		for _, lhs := range s.Lhs {
+			if ident := rootIdent(lhs); ident != nil && ident.Name != "_" {
+				names = append(names, ident.Name)
+			}
+		}
+	case *ast.DeclStmt:
+		decl, ok := s.Decl.(*ast.GenDecl)
+		if !ok {
+			break
+		}
+		for _, spec := range decl.Specs {
+			switch spec := spec.(type) {
+			case *ast.ValueSpec:
+				for _, name := range spec.Names {
+					names = append(names, name.Name)
+				}
+			case *ast.TypeSpec:
+				names = append(names, spec.Name.Name)
+			}
+		}
+	}
+	return names
+}
 
-	return comment, nil
+// rootIdent returns the variable expr selects a field or element of, or expr
+// itself if it is an identifier.
+func rootIdent(expr ast.Expr) *ast.Ident {
+	for {
+		switch x := ast.Unparen(expr).(type) {
+		case *ast.Ident:
+			return x
+		case *ast.SelectorExpr:
+			expr = x.X
+		case *ast.IndexExpr:
+			expr = x.X
+		case *ast.StarExpr:
+			expr = x.X
+		default:
+			return nil
+		}
+	}
+}
+
+// promptHints returns the framework notes added to the prompt for handler.
+func promptHints(handler scanner.Handler) string {
+	if handler.Framework == nil {
+		return ""
+	}
+	return handler.Framework.PromptHints()
 }
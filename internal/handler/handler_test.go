@@ -6,6 +6,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/insectkorea/swagGPT/internal/matcher"
 	"github.com/insectkorea/swagGPT/internal/scanner"
 	"github.com/insectkorea/swagGPT/internal/test"
 )
@@ -37,14 +38,14 @@ func Helloworld(g *gin.Context) {
 		t.Fatalf("Failed to write test Go file: %v", err)
 	}
 
-	files, err := scanner.ScanDir(tmpDir)
+	files, err := scanner.ScanDir(tmpDir, nil)
 	if err != nil {
 		t.Fatalf("Expected no error, got %v", err)
 	}
 
 	mockClient := &test.MockOpenAIClient{}
 
-	err = ProcessFiles(files, mockClient, false, "test-model", "")
+	err = ProcessFiles(ParseFiles(files, nil, scanner.Options{}), mockClient, false, "test-model", nil, scanner.Options{}, matcher.DefaultOptions)
 	if err != nil {
 		t.Fatalf("Expected no error, got %v", err)
 	}
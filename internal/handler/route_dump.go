@@ -0,0 +1,123 @@
+package handler
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/insectkorea/swagGPT/internal/model"
+)
+
+// ginDebugRoute matches the lines Gin prints in debug mode for each route, as
+// in "[GIN-debug] GET /users/:id --> example.com/app/handlers.GetUser (3 handlers)".
+var ginDebugRoute = regexp.MustCompile(`\[GIN-debug\]\s+([A-Z]+)\s+(\S+)\s+-->\s+(\S+)`)
+
+// closureName matches the names the runtime gives closures, as in func1 or 1.
+var closureName = regexp.MustCompile(`^(func)?[0-9]+$`)
+
+// echoRoute is a route of the JSON dump of Echo's e.Routes().
+type echoRoute struct {
+	Method string `json:"method"`
+	Path   string `json:"path"`
+	Name   string `json:"name"`
+}
+
+// LoadRouteDump reads the routes a running server printed: either the
+// [GIN-debug] lines Gin logs at startup, or the JSON dump of Echo's
+// e.Routes(). Routes are bound to the handlers named in the dump.
+func LoadRouteDump(path string) ([]model.Route, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read route dump %s: %v", path, err)
+	}
+
+	if json.Valid(content) {
+		var dump []echoRoute
+		if err := json.Unmarshal(content, &dump); err != nil {
+			return nil, fmt.Errorf("failed to parse route dump %s: %v", path, err)
+		}
+		var routes []model.Route
+		for _, r := range dump {
+			routes = append(routes, dumpedRoute(r.Method, r.Path, r.Name))
+		}
+		return routes, nil
+	}
+
+	var routes []model.Route
+	lines := bufio.NewScanner(bytes.NewReader(content))
+	for lines.Scan() {
+		if m := ginDebugRoute.FindStringSubmatch(lines.Text()); m != nil {
+			routes = append(routes, dumpedRoute(m[1], m[2], m[3]))
+		}
+	}
+	if err := lines.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read route dump %s: %v", path, err)
+	}
+	if len(routes) == 0 {
+		return nil, fmt.Errorf("no routes found in %s: expected [GIN-debug] lines or the JSON of Echo's e.Routes()", path)
+	}
+	return routes, nil
+}
+
+// dumpedRoute returns the route to path bound to the handler called funcName
+// by the runtime.
+func dumpedRoute(method, path, funcName string) model.Route {
+	route := model.Route{Method: method, Path: path, Pattern: path}
+	// The runtime names the main package main rather than by its path, which
+	// matcher.ResolveHandlers resolves
+	route.HandlerPackage, route.HandlerReceiver, route.Handler = parseFuncName(funcName)
+	return route
+}
+
+// parseFuncName splits a function name given by the runtime, such as
+// "example.com/app/handlers.(*UserHandler).Get-fm", into its package path,
+// receiver type and name. A closure is attributed to the function it is
+// declared in, which is the handler factory returning it.
+func parseFuncName(funcName string) (pkg, receiver, name string) {
+	slash := strings.LastIndex(funcName, "/")
+	dot := strings.Index(funcName[slash+1:], ".")
+	if dot < 0 {
+		return "", "", ""
+	}
+	pkg, rest := funcName[:slash+1+dot], funcName[slash+1+dot+1:]
+	rest = strings.TrimSuffix(rest, "-fm")
+	// Type arguments are elided, as in (*Handler[...]).Get
+	rest = strings.ReplaceAll(rest, "[...]", "")
+
+	var parts []string
+	for _, part := range strings.Split(rest, ".") {
+		if closureName.MatchString(part) {
+			break
+		}
+		parts = append(parts, part)
+	}
+
+	switch len(parts) {
+	case 0:
+		return pkg, "", ""
+	case 1:
+		return pkg, "", parts[0]
+	}
+	receiver = strings.TrimSuffix(strings.TrimPrefix(parts[0], "(*"), ")")
+	return pkg, receiver, parts[1]
+}
+
+// MergeRoutes returns routes followed by the others that are not already in
+// routes, comparing their method and full path.
+func MergeRoutes(routes []model.Route, others []model.Route) []model.Route {
+	seen := map[string]bool{}
+	merged := append([]model.Route(nil), routes...)
+	for _, route := range routes {
+		seen[route.Method+" "+route.Pattern] = true
+	}
+	for _, route := range others {
+		if !seen[route.Method+" "+route.Pattern] {
+			merged = append(merged, route)
+		}
+	}
+	return merged
+}
@@ -0,0 +1,66 @@
+package handler
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/insectkorea/swagGPT/internal/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func writeRouteDump(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "routes.txt")
+	assert.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	return path
+}
+
+func TestLoadRouteDumpGin(t *testing.T) {
+	path := writeRouteDump(t, `[GIN-debug] [WARNING] Running in "debug" mode. Switch to "release" mode in production.
+[GIN-debug] GET    /api/users/:id            --> example.com/app/handlers.(*UserHandler).Get-fm (4 handlers)
+[GIN-debug] POST   /api/users                --> example.com/app/handlers.CreateUser (4 handlers)
+2024/05/01 10:00:00 [GIN-debug] GET    /api/orders               --> example.com/app/handlers.ListOrders.func1 (3 handlers)
+[GIN-debug] PUT    /api/items/:id            --> example.com/app/handlers.(*Store[...]).Update-fm (3 handlers)
+[GIN-debug] GET    /health                   --> main.Health (3 handlers)
+[GIN-debug] Listening and serving HTTP on :8080
+`)
+
+	routes, err := LoadRouteDump(path)
+	assert.NoError(t, err)
+	assert.Equal(t, []model.Route{
+		{Method: "GET", Path: "/api/users/:id", Pattern: "/api/users/:id", Handler: "Get", HandlerPackage: "example.com/app/handlers", HandlerReceiver: "UserHandler"},
+		{Method: "POST", Path: "/api/users", Pattern: "/api/users", Handler: "CreateUser", HandlerPackage: "example.com/app/handlers"},
+		{Method: "GET", Path: "/api/orders", Pattern: "/api/orders", Handler: "ListOrders", HandlerPackage: "example.com/app/handlers"},
+		{Method: "PUT", Path: "/api/items/:id", Pattern: "/api/items/:id", Handler: "Update", HandlerPackage: "example.com/app/handlers", HandlerReceiver: "Store"},
+		{Method: "GET", Path: "/health", Pattern: "/health", Handler: "Health", HandlerPackage: "main"},
+	}, routes)
+}
+
+func TestLoadRouteDumpEcho(t *testing.T) {
+	path := writeRouteDump(t, `[
+  {"method": "GET", "path": "/users/:id", "name": "example.com/app/handlers.UserHandler.Get-fm"},
+  {"method": "DELETE", "path": "/users/:id", "name": "example.com/app/handlers.DeleteUser"}
+]`)
+
+	routes, err := LoadRouteDump(path)
+	assert.NoError(t, err)
+	assert.Equal(t, []model.Route{
+		{Method: "GET", Path: "/users/:id", Pattern: "/users/:id", Handler: "Get", HandlerPackage: "example.com/app/handlers", HandlerReceiver: "UserHandler"},
+		{Method: "DELETE", Path: "/users/:id", Pattern: "/users/:id", Handler: "DeleteUser", HandlerPackage: "example.com/app/handlers"},
+	}, routes)
+
+	_, err = LoadRouteDump(writeRouteDump(t, "no routes here\n"))
+	assert.Error(t, err)
+}
+
+func TestMergeRoutes(t *testing.T) {
+	dumped := []model.Route{
+		{Method: "GET", Path: "/users/:id", Pattern: "/users/:id", Handler: "Get", HandlerReceiver: "UserHandler"},
+	}
+	extracted := []model.Route{
+		{Method: "GET", Path: "/:id", Pattern: "/users/:id", Handler: "Get"},
+		{Method: "POST", Path: "", Pattern: "/users", Handler: "CreateUser"},
+	}
+	assert.Equal(t, []model.Route{dumped[0], extracted[1]}, MergeRoutes(dumped, extracted))
+}
@@ -0,0 +1,84 @@
+package handler
+
+import (
+	"strings"
+
+	"github.com/insectkorea/swagGPT/internal/matcher"
+	"github.com/insectkorea/swagGPT/internal/model"
+	"github.com/insectkorea/swagGPT/internal/scanner"
+)
+
+// Issues flagged in the route table.
+const (
+	// IssueNoHandler flags a route bound to none of the scanned handlers.
+	IssueNoHandler = "no handler"
+	// IssueNoRoute flags a handler no route is registered with.
+	IssueNoRoute = "no route"
+)
+
+// RouteEntry is a row of the route table: a route and the handler it is
+// bound to, or a handler no route is registered with.
+type RouteEntry struct {
+	Method  string `json:"method"`
+	Pattern string `json:"pattern"`
+	Handler string `json:"handler"`
+	// Position is where the route is registered, or where the handler is
+	// declared for a handler without a route.
+	Position string `json:"position"`
+	Issue    string `json:"issue,omitempty"`
+}
+
+// RouteTable returns an entry for each of routes, with the handlers selected
+// by opts in files it is bound to, followed by an entry for each of those
+// handlers no route is registered with.
+func RouteTable(files []File, routes []model.Route, opts scanner.Options) []RouteEntry {
+	bound := make([][]string, len(routes))
+	var unbound []RouteEntry
+	for _, file := range files {
+		for _, handler := range file.selected(opts) {
+			h := matcherHandler(handler)
+			found := false
+			for i, route := range routes {
+				if matcher.Binds(route, h) {
+					bound[i] = append(bound[i], handlerName(h.Receiver, h.Name))
+					found = true
+				}
+			}
+			if !found {
+				unbound = append(unbound, RouteEntry{
+					Handler:  handlerName(h.Receiver, h.Name),
+					Position: file.Fset.Position(handler.Decl.Pos()).String(),
+					Issue:    IssueNoRoute,
+				})
+			}
+		}
+	}
+
+	var entries []RouteEntry
+	for i, route := range routes {
+		entry := RouteEntry{
+			Method:   route.Method,
+			Pattern:  route.Pattern,
+			Handler:  strings.Join(bound[i], ", "),
+			Position: route.Position,
+		}
+		if entry.Pattern == "" {
+			entry.Pattern = route.Path
+		}
+		if len(bound[i]) == 0 {
+			entry.Handler = handlerName(route.HandlerReceiver, route.Handler)
+			entry.Issue = IssueNoHandler
+		}
+		entries = append(entries, entry)
+	}
+	return append(entries, unbound...)
+}
+
+// handlerName returns the name of a handler, qualified by its receiver type
+// for a method, as in UserHandler.Get.
+func handlerName(receiver, name string) string {
+	if receiver == "" || name == "" {
+		return name
+	}
+	return receiver + "." + name
+}
@@ -0,0 +1,45 @@
+package handler
+
+import (
+	"testing"
+
+	"github.com/insectkorea/swagGPT/internal/matcher"
+	"github.com/insectkorea/swagGPT/internal/model"
+	"github.com/insectkorea/swagGPT/internal/scanner"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRouteTable(t *testing.T) {
+	filePath := createTempGoFile(t, `package main
+
+import "github.com/gin-gonic/gin"
+
+type UserHandler struct{}
+
+func (h *UserHandler) Get(c *gin.Context) {}
+
+func (h *UserHandler) list(c *gin.Context) {}
+
+func CreateUser(c *gin.Context) {}
+
+func Orphan(c *gin.Context) {}
+`)
+	routes := []model.Route{
+		{Method: "GET", Path: "/:id", Pattern: "/users/:id", Handler: "Get", HandlerReceiver: "UserHandler", Position: "routes.go:10:2"},
+		{Method: "GET", Path: "", Pattern: "/users", Handler: "list", HandlerReceiver: "UserHandler", Position: "routes.go:11:2"},
+		{Method: "POST", Path: "/users", Handler: "CreateUser", Position: "routes.go:12:2"},
+		{Method: "DELETE", Path: "/:id", Pattern: "/users/:id", Handler: "DeleteUser", Position: "routes.go:13:2"},
+	}
+
+	opts := scanner.Options{Unexported: true}
+	files := ParseFiles([]string{filePath}, routes, opts)
+	matcher.ResolveHandlers(routes, ScanHandlers(files, opts))
+	entries := RouteTable(files, routes, opts)
+	assert.Equal(t, []RouteEntry{
+		{Method: "GET", Pattern: "/users/:id", Handler: "UserHandler.Get", Position: "routes.go:10:2"},
+		{Method: "GET", Pattern: "/users", Handler: "UserHandler.list", Position: "routes.go:11:2"},
+		{Method: "POST", Pattern: "/users", Handler: "CreateUser", Position: "routes.go:12:2"},
+		{Method: "DELETE", Pattern: "/users/:id", Handler: "DeleteUser", Position: "routes.go:13:2", Issue: IssueNoHandler},
+		{Handler: "Orphan", Position: filePath + ":13:1", Issue: IssueNoRoute},
+	}, entries)
+}
@@ -0,0 +1,33 @@
+package handler
+
+import (
+	"github.com/insectkorea/swagGPT/internal/model"
+	"github.com/insectkorea/swagGPT/internal/scanner"
+	"github.com/sirupsen/logrus"
+)
+
+// ModuleRoutes extracts the routes registered across the packages of the
+// module containing dir, following the routers passed from where they are
+// created to the functions registering routes on them.
+func ModuleRoutes(dir string) ([]model.Route, error) {
+	pkgs, err := scanner.LoadModule(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var sources []Source
+	for _, pkg := range pkgs {
+		for _, file := range pkg.Syntax {
+			sources = append(sources, Source{File: file, Info: pkg.TypesInfo, Fset: pkg.Fset, Package: pkg.PkgPath})
+		}
+	}
+
+	extractor := &RouteExtractor{
+		Routes: []model.Route{},
+	}
+	extractor.Extract(sources...)
+	for _, warning := range extractor.Warnings {
+		logrus.Warn(warning)
+	}
+	return extractor.Routes, nil
+}
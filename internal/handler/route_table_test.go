@@ -0,0 +1,68 @@
+package handler
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/insectkorea/swagGPT/internal/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestModuleRoutes(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"go.mod": "module example.com/app\n\ngo 1.21\n",
+		"cmd/api/main.go": `package main
+
+import (
+	"example.com/app/routes"
+	"github.com/gin-gonic/gin"
+)
+
+func main() {
+	r := gin.Default()
+	api := r.Group("/api")
+	routes.RegisterUsers(api.Group("/users"))
+	r.Run()
+}
+`,
+		"routes/users.go": `package routes
+
+import (
+	"example.com/app/handlers"
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterUsers(users *gin.RouterGroup) {
+	h := handlers.New()
+	users.GET("/:id", h.GetUser)
+	users.POST("", handlers.CreateUser)
+}
+`,
+		"handlers/users.go": `package handlers
+
+import "github.com/gin-gonic/gin"
+
+type UserHandler struct{}
+
+func New() *UserHandler { return &UserHandler{} }
+
+func (h *UserHandler) GetUser(c *gin.Context) {}
+
+func CreateUser(c *gin.Context) {}
+`,
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		assert.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		assert.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	}
+
+	routes, err := ModuleRoutes(filepath.Join(dir, "handlers"))
+	assert.NoError(t, err)
+	assert.Equal(t, []model.Route{
+		{Method: "GET", Path: "/:id", Pattern: "/api/users/:id", Handler: "GetUser", HandlerPackage: "example.com/app/handlers", HandlerReceiver: "UserHandler"},
+		{Method: "POST", Path: "", Pattern: "/api/users", Handler: "CreateUser", HandlerPackage: "example.com/app/handlers"},
+	}, registrations(routes))
+}
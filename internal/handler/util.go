@@ -1,40 +1,28 @@
 package handler
 
 import (
-	"bytes"
-	"go/format"
-	"go/token"
-	"os"
-
 	"github.com/insectkorea/swagGPT/internal/api"
+	"github.com/insectkorea/swagGPT/internal/matcher"
+	"github.com/insectkorea/swagGPT/internal/model"
 	"github.com/insectkorea/swagGPT/internal/scanner"
 	"github.com/sirupsen/logrus"
 )
 
-// EstimateTotalTokens estimates the total number of tokens for all handlers in the given files.
-func EstimateTotalTokens(files []string, ctxFile string) int {
+// EstimateTotalTokens estimates the total number of tokens for all handlers
+// selected by opts in the given files, along with the routes they match with
+// match.
+func EstimateTotalTokens(files []File, routes []model.Route, opts scanner.Options, match matcher.Options) int {
 	totalTokens := 0
 	for _, file := range files {
-		handlers, _, err := scanner.ParseFile(file)
-		if err != nil {
-			logrus.Errorf("Error parsing file %s: %v", file, err)
-			continue
-		}
-		for _, handler := range handlers {
-			var buf bytes.Buffer
-			if err := format.Node(&buf, token.NewFileSet(), handler); err != nil {
-				logrus.Errorf("Failed to format handler %s: %v", handler.Name.Name, err)
+		for _, handler := range file.selected(opts) {
+			handlerContent, err := handlerSource(handler.Decl)
+			if err != nil {
+				logrus.Error(err)
 				continue
 			}
-			handlerContent := buf.String()
-			totalTokens += api.EstimateTokens(handlerContent, "")
+			routeString, _ := matchRoutes(handler, boundRoutes(handler, routes), routes, match)
+			totalTokens += api.EstimateTokens(handlerContent, routeString, promptHints(handler))
 		}
 	}
-	ctxFileContent, err := os.ReadFile(ctxFile)
-	if err != nil {
-		logrus.Errorf("Error reading file %s: %v", ctxFile, err)
-		return 0
-	}
-	totalTokens += api.EstimateTokens(string(ctxFileContent), "")
 	return totalTokens
 }
@@ -0,0 +1,340 @@
+// Package mapping reads and writes the file pinning handlers to the routes
+// they serve, along with their tags and security, which takes precedence over
+// the routes extracted from the source and over the routes guessed from
+// handler names.
+package mapping
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/insectkorea/swagGPT/internal/matcher"
+	"github.com/insectkorea/swagGPT/internal/model"
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultFile is the name of the mapping file looked up in the scanned
+// directory.
+const DefaultFile = "swaggpt.routes.yaml"
+
+// File pins handlers to the routes they serve.
+type File struct {
+	Handlers []Entry `yaml:"handlers"`
+	// doc is the document f was read from, which Save edits so as to keep
+	// its comments and the style of the entries it does not change.
+	doc *yaml.Node
+}
+
+// Entry pins a handler to routes.
+type Entry struct {
+	// Handler names the handler as Name, Receiver.Name, package.Name or
+	// package.Receiver.Name, where package is the import path or name of
+	// the package declaring it.
+	Handler string `yaml:"handler"`
+	// Routes are the routes the handler serves, as "METHOD /path" with the
+	// full path in OpenAPI syntax, as in "GET /api/users/{id}". An entry
+	// without routes pins a handler that serves none.
+	Routes []string `yaml:"routes"`
+	// Tags replace the @Tags the model generates for the handler, and
+	// Security lists the security schemes of its routes, added to those of
+	// their middleware.
+	Tags     []string `yaml:"tags,omitempty"`
+	Security []string `yaml:"security,omitempty"`
+}
+
+// Path returns the path of the mapping file: path if it is set, or else the
+// default file of dir.
+func Path(path string, dir string) string {
+	if path != "" {
+		return path
+	}
+	return filepath.Join(dir, DefaultFile)
+}
+
+// Load reads the mapping file at path. A missing file is an empty mapping.
+func Load(path string) (*File, error) {
+	content, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return &File{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read mapping %s: %v", path, err)
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(content, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse mapping %s: %v", path, err)
+	}
+	f := File{doc: &doc}
+	if err := doc.Decode(&f); err != nil {
+		return nil, fmt.Errorf("failed to parse mapping %s: %v", path, err)
+	}
+	for i, entry := range f.Handlers {
+		if entry.Handler == "" {
+			return nil, fmt.Errorf("invalid mapping %s: entry %d has no handler", path, i+1)
+		}
+		for _, route := range entry.Routes {
+			if _, _, ok := ParseRoute(route); !ok {
+				return nil, fmt.Errorf("invalid mapping %s: route %q of %s is not of the form \"METHOD /path\"", path, route, entry.Handler)
+			}
+		}
+	}
+	return &f, nil
+}
+
+// Save writes f to path. A file that was loaded keeps its comments and the
+// entries whose routes did not change are written as they were read.
+func (f *File) Save(path string) error {
+	doc, err := f.document()
+	if err != nil {
+		return fmt.Errorf("failed to encode mapping: %v", err)
+	}
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(doc); err != nil {
+		return fmt.Errorf("failed to encode mapping: %v", err)
+	}
+	if err := os.WriteFile(path, buf.Bytes(), 0644); err != nil {
+		return fmt.Errorf("failed to write mapping %s: %v", path, err)
+	}
+	return nil
+}
+
+// document returns the document f was read from, with the routes of its
+// entries updated and the entries pinned since appended, or a new document if
+// f was not read from a file.
+func (f *File) document() (*yaml.Node, error) {
+	if f.doc == nil || len(f.doc.Content) == 0 || f.doc.Content[0].Kind != yaml.MappingNode {
+		doc := &yaml.Node{}
+		return doc, doc.Encode(f)
+	}
+
+	root := f.doc.Content[0]
+	entries := value(root, "handlers")
+	if entries == nil || entries.Kind != yaml.SequenceNode {
+		entries = &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+		setValue(root, "handlers", entries)
+	}
+	for i, entry := range f.Handlers {
+		if i >= len(entries.Content) {
+			node := &yaml.Node{}
+			if err := node.Encode(entry); err != nil {
+				return nil, err
+			}
+			entries.Content = append(entries.Content, node)
+			continue
+		}
+
+		var read Entry
+		if err := entries.Content[i].Decode(&read); err == nil && slices.Equal(read.Routes, entry.Routes) {
+			continue
+		}
+		routes := &yaml.Node{}
+		if err := routes.Encode(entry.Routes); err != nil {
+			return nil, err
+		}
+		setValue(entries.Content[i], "routes", routes)
+	}
+	return f.doc, nil
+}
+
+// value returns the value of key in the mapping node m, or nil if it has none.
+func value(m *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(m.Content); i += 2 {
+		if m.Content[i].Value == key {
+			return m.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// setValue sets the value of key in the mapping node m, keeping the comments
+// of the value it replaces.
+func setValue(m *yaml.Node, key string, v *yaml.Node) {
+	for i := 0; i+1 < len(m.Content); i += 2 {
+		if m.Content[i].Value == key {
+			old := m.Content[i+1]
+			v.HeadComment, v.LineComment, v.FootComment = old.HeadComment, old.LineComment, old.FootComment
+			m.Content[i+1] = v
+			return
+		}
+	}
+	m.Content = append(m.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key}, v)
+}
+
+// Pin pins the handler named handler to routes, replacing its entry if it has
+// one.
+func (f *File) Pin(handler string, routes []string) {
+	for i, entry := range f.Handlers {
+		if entry.Handler == handler {
+			f.Handlers[i].Routes = routes
+			return
+		}
+	}
+	f.Handlers = append(f.Handlers, Entry{Handler: handler, Routes: routes})
+}
+
+// Lookup returns the first entry naming h.
+func (f *File) Lookup(h matcher.Handler) (Entry, bool) {
+	for _, entry := range f.Handlers {
+		if entry.Names(h) {
+			return entry, true
+		}
+	}
+	return Entry{}, false
+}
+
+// Apply returns routes with the routes of the handlers pinned by f replaced by
+// the routes they are pinned to, along with the handlers pinned to no route.
+// handlers are the scanned handlers f applies to. A pinned route registered in
+// the source keeps its middleware and position, and is no longer bound to
+// another handler.
+func (f *File) Apply(handlers []matcher.Handler, routes []model.Route) ([]model.Route, []matcher.Handler) {
+	var pinnedHandlers, unrouted []matcher.Handler
+	var pinned []model.Route
+	for _, h := range handlers {
+		entry, ok := f.Lookup(h)
+		if !ok {
+			continue
+		}
+		pinnedHandlers = append(pinnedHandlers, h)
+		if len(entry.Routes) == 0 {
+			unrouted = append(unrouted, h)
+		}
+		for _, r := range entry.Routes {
+			method, path, _ := ParseRoute(r)
+			route := model.Route{Method: method, Path: path, Pattern: path}
+			if i := findRoute(routes, method, path); i >= 0 {
+				route = routes[i]
+				route.RouterPath = ""
+			}
+			route.Handler, route.HandlerReceiver, route.HandlerPackage = h.Name, h.Receiver, h.Package
+			route.Tags = entry.Tags
+			route.Security = slices.Clone(route.Security)
+			for _, scheme := range entry.Security {
+				if !slices.Contains(route.Security, scheme) {
+					route.Security = append(route.Security, scheme)
+				}
+			}
+			pinned = append(pinned, route)
+		}
+	}
+
+	var kept []model.Route
+	for _, route := range routes {
+		if slices.ContainsFunc(pinnedHandlers, func(h matcher.Handler) bool { return matcher.Binds(route, h) }) {
+			continue
+		}
+		if findRoute(pinned, route.Method, matcher.SwaggerPath(routePattern(route), "")) >= 0 {
+			continue
+		}
+		kept = append(kept, route)
+	}
+	return append(kept, pinned...), unrouted
+}
+
+// CheckHandlers returns a warning for each entry of f naming none of handlers.
+func (f *File) CheckHandlers(handlers []matcher.Handler) []string {
+	var warnings []string
+	for _, entry := range f.Handlers {
+		if !slices.ContainsFunc(handlers, entry.Names) {
+			warnings = append(warnings, fmt.Sprintf("pinned handler %s was not found", entry.Handler))
+		}
+	}
+	return warnings
+}
+
+// CheckRoutes returns a warning for each pinned route missing from routes, the
+// routes found in the source. Routes are not checked if none were found.
+func (f *File) CheckRoutes(routes []model.Route) []string {
+	if len(routes) == 0 {
+		return nil
+	}
+	var warnings []string
+	for _, entry := range f.Handlers {
+		for _, r := range entry.Routes {
+			if method, path, _ := ParseRoute(r); findRoute(routes, method, path) < 0 {
+				warnings = append(warnings, fmt.Sprintf("route %s pinned to %s was not found", r, entry.Handler))
+			}
+		}
+	}
+	return warnings
+}
+
+// Key returns the name h is pinned by: package.Name for a function and
+// package.Receiver.Name for a method, package being its import path.
+func Key(h matcher.Handler) string {
+	key := h.Name
+	if h.Receiver != "" {
+		key = h.Receiver + "." + key
+	}
+	if h.Package != "" {
+		key = h.Package + "." + key
+	}
+	return key
+}
+
+// Names reports whether e pins h.
+func (e Entry) Names(h matcher.Handler) bool {
+	// Import paths may contain dots before their last slash
+	prefix, rest := "", e.Handler
+	if i := strings.LastIndex(rest, "/"); i >= 0 {
+		prefix, rest = rest[:i+1], rest[i+1:]
+	}
+	parts := strings.Split(rest, ".")
+	if parts[len(parts)-1] != h.Name {
+		return false
+	}
+	inPackage := func(name string) bool {
+		if prefix != "" || strings.Contains(h.Package, "/") {
+			return h.Package == prefix+name || (prefix == "" && path.Base(h.Package) == name)
+		}
+		return h.Package == name
+	}
+
+	switch len(parts) {
+	case 1:
+		return prefix == ""
+	case 2:
+		return (prefix == "" && parts[0] == h.Receiver) || (h.Receiver == "" && inPackage(parts[0]))
+	case 3:
+		return inPackage(parts[0]) && parts[1] == h.Receiver
+	}
+	return false
+}
+
+// FormatRoute formats route as pinned, as in "GET /api/users/{id}".
+func FormatRoute(route model.Route) string {
+	return strings.ToUpper(route.Method) + " " + matcher.SwaggerPath(routePattern(route), "")
+}
+
+// ParseRoute parses a pinned route of the form "METHOD /path".
+func ParseRoute(route string) (method string, path string, ok bool) {
+	fields := strings.Fields(route)
+	if len(fields) != 2 || !strings.HasPrefix(fields[1], "/") {
+		return "", "", false
+	}
+	return strings.ToUpper(fields[0]), fields[1], true
+}
+
+// findRoute returns the index of the route of routes with method and the full
+// path in OpenAPI syntax, or -1.
+func findRoute(routes []model.Route, method string, path string) int {
+	return slices.IndexFunc(routes, func(route model.Route) bool {
+		return strings.EqualFold(route.Method, method) && matcher.SwaggerPath(routePattern(route), "") == path
+	})
+}
+
+// routePattern returns the full path of route.
+func routePattern(route model.Route) string {
+	if route.Pattern != "" {
+		return route.Pattern
+	}
+	return route.Path
+}
@@ -0,0 +1,200 @@
+package mapping
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/insectkorea/swagGPT/internal/matcher"
+	"github.com/insectkorea/swagGPT/internal/model"
+)
+
+func TestLoadAndSave(t *testing.T) {
+	path := filepath.Join(t.TempDir(), DefaultFile)
+
+	f, err := Load(path)
+	if err != nil {
+		t.Fatalf("Expected no error for a missing mapping, got %v", err)
+	}
+	if len(f.Handlers) != 0 {
+		t.Fatalf("Expected an empty mapping, got %+v", f)
+	}
+
+	f.Pin("example.com/app/api.UserHandler.Get", []string{"GET /users/{id}"})
+	f.Pin("example.com/app/api.Health", []string{})
+	f.Pin("example.com/app/api.UserHandler.Get", []string{"GET /users/{id}", "HEAD /users/{id}"})
+	if err := f.Save(path); err != nil {
+		t.Fatalf("Failed to save mapping: %v", err)
+	}
+
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	expected := []Entry{
+		{Handler: "example.com/app/api.UserHandler.Get", Routes: []string{"GET /users/{id}", "HEAD /users/{id}"}},
+		{Handler: "example.com/app/api.Health", Routes: []string{}},
+	}
+	if !reflect.DeepEqual(loaded.Handlers, expected) {
+		t.Fatalf("Expected %+v, got %+v", expected, loaded.Handlers)
+	}
+}
+
+func TestSaveKeepsComments(t *testing.T) {
+	path := filepath.Join(t.TempDir(), DefaultFile)
+	content := `# Routes of the handlers the matcher gets wrong
+handlers:
+  # Listed under two versions
+  - handler: api.UserHandler.List
+    routes: [GET /api/users, GET /api/v1/users] # v1 is deprecated
+    tags: [users]
+  - handler: api.UserHandler.Get
+    routes: ["GET /api/users/{id}"]
+`
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write mapping: %v", err)
+	}
+
+	f, err := Load(path)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	f.Pin("api.UserHandler.Get", []string{"GET /api/users/{id}", "HEAD /api/users/{id}"})
+	f.Pin("api.Health", []string{})
+	if err := f.Save(path); err != nil {
+		t.Fatalf("Failed to save mapping: %v", err)
+	}
+
+	saved, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read mapping: %v", err)
+	}
+	expected := `# Routes of the handlers the matcher gets wrong
+handlers:
+  # Listed under two versions
+  - handler: api.UserHandler.List
+    routes: [GET /api/users, GET /api/v1/users] # v1 is deprecated
+    tags: [users]
+  - handler: api.UserHandler.Get
+    routes:
+      - GET /api/users/{id}
+      - HEAD /api/users/{id}
+  - handler: api.Health
+    routes: []
+`
+	if string(saved) != expected {
+		t.Fatalf("Expected\n%s\ngot\n%s", expected, saved)
+	}
+}
+
+func TestLoadInvalid(t *testing.T) {
+	for _, content := range []string{
+		"handlers:\n  - routes: [GET /users]\n",
+		"handlers:\n  - handler: ListUsers\n    routes: [/users]\n",
+		"handlers:\n  - handler: ListUsers\n    routes: [GET users]\n",
+	} {
+		path := filepath.Join(t.TempDir(), DefaultFile)
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write mapping: %v", err)
+		}
+		if _, err := Load(path); err == nil {
+			t.Errorf("Expected an error for %q", content)
+		}
+	}
+}
+
+func TestEntryNames(t *testing.T) {
+	method := matcher.Handler{Name: "Get", Receiver: "UserHandler", Package: "example.com/app/api"}
+	function := matcher.Handler{Name: "Health", Package: "example.com/app/api"}
+
+	testCases := []struct {
+		handler  string
+		h        matcher.Handler
+		expected bool
+	}{
+		{"Get", method, true},
+		{"UserHandler.Get", method, true},
+		{"api.UserHandler.Get", method, true},
+		{"example.com/app/api.UserHandler.Get", method, true},
+		{"example.com/other/api.UserHandler.Get", method, false},
+		{"OrgHandler.Get", method, false},
+		{"api.Get", method, false},
+		{"Health", function, true},
+		{"api.Health", function, true},
+		{"example.com/app/api.Health", function, true},
+		{"app.Health", function, false},
+		{"UserHandler.Health", function, false},
+	}
+	for _, tc := range testCases {
+		if got := (Entry{Handler: tc.handler}).Names(tc.h); got != tc.expected {
+			t.Errorf("Entry %q names %+v: got %v, expected %v", tc.handler, tc.h, got, tc.expected)
+		}
+	}
+
+	if key := Key(method); key != "example.com/app/api.UserHandler.Get" {
+		t.Errorf("Unexpected key %q", key)
+	}
+	if !(Entry{Handler: Key(function)}).Names(function) {
+		t.Errorf("Expected the key of %+v to name it", function)
+	}
+}
+
+func TestApply(t *testing.T) {
+	list := matcher.Handler{Name: "ListUsers", Package: "example.com/app/api"}
+	get := matcher.Handler{Name: "Get", Receiver: "UserHandler", Package: "example.com/app/api"}
+	health := matcher.Handler{Name: "Health", Package: "example.com/app/api"}
+	routes := []model.Route{
+		{Method: "GET", Path: "/users", Pattern: "/api/users", Handler: "Index", Middleware: []string{"auth.Required"}, Security: []string{"BearerAuth"}},
+		{Method: "GET", Path: "/:id", Pattern: "/api/users/:id", Position: "router.go:12"},
+		{Method: "GET", Path: "/health", Pattern: "/health", Handler: "Health", HandlerPackage: "example.com/app/api"},
+		{Method: "GET", Path: "/orgs", Pattern: "/api/orgs"},
+	}
+	f := &File{Handlers: []Entry{
+		{Handler: "ListUsers", Routes: []string{"GET /api/users"}, Tags: []string{"users"}, Security: []string{"BearerAuth", "ApiKeyAuth"}},
+		{Handler: "UserHandler.Get", Routes: []string{"GET /api/users/{id}", "HEAD /api/users/{id}"}},
+		{Handler: "api.Health", Routes: []string{}},
+		{Handler: "Missing", Routes: []string{"GET /missing"}},
+	}}
+
+	applied, unrouted := f.Apply([]matcher.Handler{list, get, health}, routes)
+	expected := []model.Route{
+		{Method: "GET", Path: "/orgs", Pattern: "/api/orgs"},
+		{Method: "GET", Path: "/users", Pattern: "/api/users", Handler: "ListUsers", HandlerPackage: "example.com/app/api", Middleware: []string{"auth.Required"}, Security: []string{"BearerAuth", "ApiKeyAuth"}, Tags: []string{"users"}},
+		{Method: "GET", Path: "/:id", Pattern: "/api/users/:id", Position: "router.go:12", Handler: "Get", HandlerReceiver: "UserHandler", HandlerPackage: "example.com/app/api"},
+		{Method: "HEAD", Path: "/api/users/{id}", Pattern: "/api/users/{id}", Handler: "Get", HandlerReceiver: "UserHandler", HandlerPackage: "example.com/app/api"},
+	}
+	if !reflect.DeepEqual(applied, expected) {
+		t.Fatalf("Expected routes %+v, got %+v", expected, applied)
+	}
+	if !reflect.DeepEqual(unrouted, []matcher.Handler{health}) {
+		t.Fatalf("Expected %+v to be unrouted, got %+v", health, unrouted)
+	}
+	if !reflect.DeepEqual(routes[0].Security, []string{"BearerAuth"}) {
+		t.Fatalf("Expected the extracted routes to be left unchanged, got %+v", routes[0])
+	}
+}
+
+func TestCheck(t *testing.T) {
+	handlers := []matcher.Handler{{Name: "ListUsers", Package: "example.com/app/api"}}
+	routes := []model.Route{{Method: "GET", Path: "/:id", Pattern: "/api/users/:id"}}
+	f := &File{Handlers: []Entry{
+		{Handler: "api.ListUsers", Routes: []string{"GET /api/users/{id}", "GET /api/accounts"}},
+		{Handler: "api.DeleteUser", Routes: []string{"DELETE /api/users/{id}"}},
+	}}
+
+	expected := []string{"pinned handler api.DeleteUser was not found"}
+	if warnings := f.CheckHandlers(handlers); !reflect.DeepEqual(warnings, expected) {
+		t.Fatalf("Expected warnings %q, got %q", expected, warnings)
+	}
+	expected = []string{
+		"route GET /api/accounts pinned to api.ListUsers was not found",
+		"route DELETE /api/users/{id} pinned to api.DeleteUser was not found",
+	}
+	if warnings := f.CheckRoutes(routes); !reflect.DeepEqual(warnings, expected) {
+		t.Fatalf("Expected warnings %q, got %q", expected, warnings)
+	}
+	if warnings := f.CheckRoutes(nil); warnings != nil {
+		t.Fatalf("Expected no warnings without routes, got %q", warnings)
+	}
+}
@@ -2,82 +2,263 @@ package matcher
 
 import (
 	"fmt"
-	"sort"
+	"slices"
 	"strings"
 
 	"github.com/insectkorea/swagGPT/internal/model"
 )
 
-type matchedRoute struct {
-	Route       string
-	Method      string
-	Substrings  []string
-	TotalLength int
+// Handler identifies a handler function or method.
+type Handler struct {
+	Name string
+	// Receiver is the receiver type name of a method.
+	Receiver string
+	// Package is the import path of the package declaring the handler.
+	Package string
 }
 
-func MatchHandlerToRoute(handlerSignature string, routes []model.Route) (string, error) {
-	// Find the matching routes based on the number of substrings and total length of matched substrings
-	var matchingRoutes []matchedRoute
+// BoundRoutes returns the routes registered with handler.
+func BoundRoutes(handler Handler, routes []model.Route) []model.Route {
+	var bound []model.Route
 	for _, route := range routes {
-		substrings, totalLength := findMatchedSubstrings(handlerSignature, route)
-		if len(substrings) > 0 {
-			matchingRoutes = append(matchingRoutes, matchedRoute{
-				Route:       route.Path,
-				Method:      strings.ToLower(route.Method),
-				Substrings:  substrings,
-				TotalLength: totalLength,
-			})
+		if Binds(route, handler) {
+			bound = append(bound, route)
 		}
 	}
+	return bound
+}
 
-	// Prioritize the matching routes based on the number of substrings and total length of matched substrings
-	sortMatchingRoutes(matchingRoutes)
-	routeString := ""
-	for i, route := range matchingRoutes {
-		if i > 3 {
-			// Only consider the top 3 routes
-			break
+// Binds reports whether route is registered with handler: the function or
+// method of that very name, receiver type and package. Routes whose handler
+// is only partly known are bound once ResolveHandlers completes them.
+func Binds(route model.Route, handler Handler) bool {
+	return route.Handler != "" && route.Handler == handler.Name &&
+		route.HandlerReceiver == handler.Receiver && route.HandlerPackage == handler.Package
+}
+
+// mainPackage is the package name the runtime gives the handlers of a main
+// package, whose import path it does not tell.
+const mainPackage = "main"
+
+// ResolveHandlers completes the handler of each of routes whose package is
+// unknown with the receiver type and package of the one handler among
+// handlers it may refer to, if there is exactly one. The handlers of other
+// routes are left as they are, so that the routes are matched to handlers by
+// name rather than bound to every handler of the same name.
+func ResolveHandlers(routes []model.Route, handlers []Handler) {
+	for i, route := range routes {
+		if route.Handler == "" || (route.HandlerPackage != "" && route.HandlerPackage != mainPackage) {
+			continue
+		}
+		var found []Handler
+		for _, h := range handlers {
+			if mayRefer(route, h) {
+				found = append(found, h)
+			}
+		}
+		if len(found) == 1 {
+			routes[i].HandlerReceiver, routes[i].HandlerPackage = found[0].Receiver, found[0].Package
+		}
+	}
+}
+
+// mayRefer reports whether the partly known handler of route may be handler.
+// The receiver type of a route of the main package is known, as the runtime
+// names it, while an empty receiver of a route of an unknown package may be
+// that of a method whose receiver could not be resolved.
+func mayRefer(route model.Route, handler Handler) bool {
+	if route.Handler != handler.Name {
+		return false
+	}
+	if route.HandlerPackage == mainPackage {
+		return route.HandlerReceiver == handler.Receiver
+	}
+	return route.HandlerReceiver == "" || route.HandlerReceiver == handler.Receiver
+}
+
+// FormatRoutes formats routes as a route string such as
+// "/users/{id} [get], /users/{id} [put]".
+func FormatRoutes(routes []model.Route) string {
+	var routeStrings []string
+	for _, route := range routes {
+		routeStrings = append(routeStrings, fmt.Sprintf("%s [%s]", RouterPath(route), strings.ToLower(route.Method)))
+	}
+	return strings.Join(routeStrings, ", ")
+}
+
+// SetRouterPaths sets the path each of routes is documented with, relative to
+// basePath, the @BasePath of the API.
+func SetRouterPaths(routes []model.Route, basePath string) {
+	for i := range routes {
+		routes[i].RouterPath = SwaggerPath(routePattern(routes[i]), basePath)
+	}
+}
+
+// RouterPath returns the path route is documented with in its @Router line.
+func RouterPath(route model.Route) string {
+	if route.RouterPath != "" {
+		return route.RouterPath
+	}
+	return SwaggerPath(routePattern(route), "")
+}
+
+// SwaggerPath returns pattern in the syntax of @Router paths, relative to
+// basePath and with the :id and *filepath parameters of Gin, Echo and Fiber
+// written {id} and {filepath}. Fiber's optional marker and constraints, as in
+// :id<int>?, are dropped, and unnamed wildcards, as in Echo's /static/*, are
+// written {wildcard}. Placeholders keep their name only, without the regexp of
+// chi and gorilla/mux, as in {id:[0-9]+}, or the ... of ServeMux wildcards, as
+// in {path...}.
+func SwaggerPath(pattern string, basePath string) string {
+	basePath = strings.TrimSuffix(basePath, "/")
+	if basePath != "" && (pattern == basePath || strings.HasPrefix(pattern, basePath+"/")) {
+		pattern = strings.TrimPrefix(pattern, basePath)
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(pattern); i++ {
+		c := pattern[i]
+		if c == '{' {
+			// Already a placeholder, whose regexp may contain any of :*+{}
+			end := placeholderEnd(pattern[i:])
+			if end < 0 {
+				b.WriteString(pattern[i:])
+				break
+			}
+			// ServeMux's {$} only anchors the end of the path
+			if name := paramName(pattern[i+1 : i+end]); name != "$" {
+				b.WriteString("{" + name + "}")
+			}
+			i += end
+			continue
 		}
-		routeString += fmt.Sprintf("%s [%s]", route.Route, route.Method)
-		if i < len(matchingRoutes)-1 {
-			routeString += ", "
+		if c != ':' && c != '*' && c != '+' {
+			b.WriteByte(c)
+			continue
 		}
+
+		j := i + 1
+		for j < len(pattern) && isNameByte(pattern[j]) {
+			j++
+		}
+		name := pattern[i+1 : j]
+		if name == "" {
+			if c == ':' {
+				b.WriteByte(c)
+				continue
+			}
+			name = "wildcard"
+		}
+		for j < len(pattern) && (pattern[j] == '?' || pattern[j] == '<') {
+			if pattern[j] == '?' {
+				j++
+			} else if end := strings.IndexByte(pattern[j:], '>'); end >= 0 {
+				j += end + 1
+			} else {
+				break
+			}
+		}
+		b.WriteString("{" + name + "}")
+		i = j - 1
 	}
 
-	return routeString, nil
+	if b.Len() == 0 {
+		return "/"
+	}
+	return b.String()
 }
 
-// sortMatchingRoutes sorts the matching routes based on the number of substrings and total length of matched substrings
-func sortMatchingRoutes(routes []matchedRoute) {
-	sort.Slice(routes, func(i, j int) bool {
-		// Sort by the number of substrings in descending order
-		if len(routes[i].Substrings) != len(routes[j].Substrings) {
-			return len(routes[i].Substrings) > len(routes[j].Substrings)
+// PathParams returns the names of the {name} parameters of path, without
+// their regexp or trailing ..., in order and without duplicates.
+func PathParams(path string) []string {
+	var names []string
+	for i := strings.IndexByte(path, '{'); i >= 0; i = strings.IndexByte(path, '{') {
+		end := placeholderEnd(path[i:])
+		if end < 0 {
+			break
 		}
-		// If the number of substrings is the same, sort by the total length of matched substrings in descending order
-		return routes[i].TotalLength > routes[j].TotalLength
-	})
-}
-
-// findMatchedSubstrings finds the substrings in the path that match the handler signature
-func findMatchedSubstrings(handlerSignature string, route model.Route) ([]string, int) {
-	var substrings []string
-	totalLength := 0
-	// preprocess the handlerSignature and route to lowercase and trim spaces
-	handlerSignature = strings.TrimSpace(handlerSignature)
-	handlerSignature = strings.ToLower(handlerSignature)
-	path := strings.TrimSpace(route.Path)
-	path = strings.ToLower(path)
-
-	for i := 0; i < len(path); i++ {
-		for j := i + 1; j <= len(path); j++ {
-			substring := path[i:j]
-			if len(substring) > 3 && strings.Contains(handlerSignature, substring) {
-				substrings = append(substrings, substring)
-				totalLength += len(substring)
+		if name := paramName(path[i+1 : i+end]); name != "$" && !slices.Contains(names, name) {
+			names = append(names, name)
+		}
+		path = path[i+end+1:]
+	}
+	return names
+}
+
+// placeholderEnd returns the index of the brace closing the placeholder s
+// starts with, skipping those of its regexp, as in {id:[0-9]{3}}, or -1 if it
+// is not closed.
+func placeholderEnd(s string) int {
+	depth := 0
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '{':
+			depth++
+		case '}':
+			if depth--; depth == 0 {
+				return i
 			}
 		}
 	}
+	return -1
+}
+
+// paramName returns the name of the placeholder {placeholder}, without the
+// regexp of chi and gorilla/mux or the ... of ServeMux wildcards.
+func paramName(placeholder string) string {
+	name, _, _ := strings.Cut(placeholder, ":")
+	return strings.TrimSuffix(strings.TrimSpace(name), "...")
+}
+
+// isNameByte reports whether c may appear in the name of a path parameter.
+func isNameByte(c byte) bool {
+	return c == '_' || '0' <= c && c <= '9' || 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z'
+}
+
+// routePattern returns the full path of route, including the prefixes of the
+// groups it is registered in.
+func routePattern(route model.Route) string {
+	if route.Pattern != "" {
+		return route.Pattern
+	}
+	return route.Path
+}
+
+// Options selects the candidates MatchHandlerToRoute returns.
+type Options struct {
+	// TopK is the maximum number of candidates, or 0 for no limit.
+	TopK int
+	// MinScore is the score below which candidates are dropped.
+	MinScore float64
+	// Unrouted lists the handlers known to serve none of the routes, which
+	// get no candidates.
+	Unrouted []Handler
+}
+
+// DefaultOptions keeps the three best candidates scoring at least 0.3.
+var DefaultOptions = Options{TopK: 3, MinScore: 0.3}
+
+// MatchHandlerToRoute returns the routes handler most likely serves, as ranked
+// by RankRoutes and selected by opts.
+func MatchHandlerToRoute(handler Handler, routes []model.Route, opts Options) []Candidate {
+	if slices.Contains(opts.Unrouted, handler) {
+		return nil
+	}
+	var candidates []Candidate
+	for _, candidate := range RankRoutes(handler, routes) {
+		if candidate.Score < opts.MinScore || (opts.TopK > 0 && len(candidates) == opts.TopK) {
+			break
+		}
+		candidates = append(candidates, candidate)
+	}
+	return candidates
+}
 
-	return substrings, totalLength
+// CandidateRoutes returns the routes of candidates.
+func CandidateRoutes(candidates []Candidate) []model.Route {
+	var routes []model.Route
+	for _, candidate := range candidates {
+		routes = append(routes, candidate.Route)
+	}
+	return routes
 }
@@ -12,8 +12,8 @@ func TestMatchHandlerToRoute(t *testing.T) {
 		name             string
 		handlerSignature string
 		routes           []model.Route
+		opts             Options
 		expectedRoute    string
-		expectedError    error
 	}{
 		{
 			name:             "MatchingRoute",
@@ -22,8 +22,7 @@ func TestMatchHandlerToRoute(t *testing.T) {
 				{Method: "GET", Path: "/api/v1/organizations/:organization_id/bundles", Pattern: "/api/v1/organizations/:organization_id/bundles"},
 				{Method: "GET", Path: "/api/v1/organizations/:organization_id/invitations", Pattern: "/api/v1/organizations/:organization_id/invitations"},
 			},
-			expectedRoute: "/api/v1/organizations/:organization_id/bundles [get]",
-			expectedError: nil,
+			expectedRoute: "/api/v1/organizations/{organization_id}/bundles [get], /api/v1/organizations/{organization_id}/invitations [get]",
 		},
 		{
 			name:             "NonMatchingRoute",
@@ -33,7 +32,6 @@ func TestMatchHandlerToRoute(t *testing.T) {
 				{Method: "GET", Path: "/api/v1/organizations/:organization_id/invitations", Pattern: "/api/v1/organizations/:organization_id/invitations"},
 			},
 			expectedRoute: "",
-			expectedError: nil,
 		},
 		{
 			name:             "MultipleMatchingRoutes",
@@ -42,22 +40,161 @@ func TestMatchHandlerToRoute(t *testing.T) {
 				{Method: "GET", Path: "/api/v1/organizations/:organization_id/bundles", Pattern: "/api/v1/organizations/:organization_id/bundles"},
 				{Method: "GET", Path: "/api/v1/organizations/:organization_id/invitations", Pattern: "/api/v1/organizations/:organization_id/invitations"},
 			},
-			expectedRoute: "/api/v1/organizations/:organization_id/bundles [get]",
-			expectedError: nil,
+			expectedRoute: "/api/v1/organizations/{organization_id}/bundles [get], /api/v1/organizations/{organization_id}/invitations [get]",
+		},
+		{
+			name:             "TopK",
+			handlerSignature: "OrgHandler_List",
+			routes: []model.Route{
+				{Method: "GET", Path: "/api/v1/organizations/:organization_id/bundles", Pattern: "/api/v1/organizations/:organization_id/bundles"},
+				{Method: "GET", Path: "/api/v1/organizations/:organization_id/invitations", Pattern: "/api/v1/organizations/:organization_id/invitations"},
+			},
+			opts:          Options{TopK: 1},
+			expectedRoute: "/api/v1/organizations/{organization_id}/bundles [get]",
+		},
+		{
+			name:             "Unrouted",
+			handlerSignature: "OrgHandler_List",
+			routes: []model.Route{
+				{Method: "GET", Path: "/api/v1/organizations/:organization_id/bundles", Pattern: "/api/v1/organizations/:organization_id/bundles"},
+			},
+			opts:          Options{Unrouted: []Handler{{Name: "OrgHandler_List"}}},
+			expectedRoute: "",
+		},
+		{
+			name:             "BelowThreshold",
+			handlerSignature: "DeleteOrgBundleInvitation",
+			routes: []model.Route{
+				{Method: "GET", Path: "/api/v1/organizations/:organization_id/bundles", Pattern: "/api/v1/organizations/:organization_id/bundles"},
+			},
+			opts:          Options{MinScore: 0.6},
+			expectedRoute: "",
 		},
 	}
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			route, err := MatchHandlerToRoute(tc.handlerSignature, tc.routes)
+			route := FormatRoutes(CandidateRoutes(MatchHandlerToRoute(Handler{Name: tc.handlerSignature}, tc.routes, tc.opts)))
 
 			if !reflect.DeepEqual(route, tc.expectedRoute) {
 				t.Errorf("Unexpected route. Got %+v, expected %+v", route, tc.expectedRoute)
 			}
+		})
+	}
+}
+
+func TestBoundRoutes(t *testing.T) {
+	routes := []model.Route{
+		{Method: "GET", Path: "/:id", Pattern: "/users/:id", Handler: "Get", HandlerReceiver: "UserHandler"},
+		{Method: "GET", Path: "/:id", Pattern: "/orders/:id", Handler: "Get", HandlerReceiver: "OrderHandler"},
+		{Method: "POST", Path: "/", Pattern: "/orders/", Handler: "CreateOrder", HandlerPackage: "example.com/app/orders"},
+		{Method: "PUT", Path: "/:id", Pattern: "/orders/:id", Handler: "UpdateOrder", HandlerPackage: "example.com/app/orders"},
+		{Method: "PATCH", Path: "/:id", Pattern: "/orders/:id", Handler: "UpdateOrder", HandlerPackage: "example.com/app/orders"},
+		{Method: "DELETE", Path: "/:id", Pattern: "/orders/:id", Handler: "DeleteOrder"},
+	}
+
+	testCases := []struct {
+		name          string
+		handler       Handler
+		expectedRoute string
+	}{
+		{name: "Receiver", handler: Handler{Name: "Get", Receiver: "OrderHandler"}, expectedRoute: "/orders/{id} [get]"},
+		{name: "Package", handler: Handler{Name: "CreateOrder", Package: "example.com/app/orders"}, expectedRoute: "/orders/ [post]"},
+		{name: "OtherPackage", handler: Handler{Name: "CreateOrder", Package: "example.com/app/legacy"}, expectedRoute: ""},
+		{name: "MultipleRoutes", handler: Handler{Name: "UpdateOrder", Package: "example.com/app/orders"}, expectedRoute: "/orders/{id} [put], /orders/{id} [patch]"},
+		{name: "OtherReceiver", handler: Handler{Name: "Get", Receiver: "AdminHandler"}, expectedRoute: ""},
+		{name: "PartlyKnown", handler: Handler{Name: "DeleteOrder", Package: "example.com/app/orders"}, expectedRoute: ""},
+		{name: "Unbound", handler: Handler{Name: "ListOrders"}, expectedRoute: ""},
+	}
 
-			if (err == nil) != (tc.expectedError == nil) || (err != nil && tc.expectedError != nil && err.Error() != tc.expectedError.Error()) {
-				t.Errorf("Unexpected error. Got %v, expected %v", err, tc.expectedError)
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			route := FormatRoutes(BoundRoutes(tc.handler, routes))
+			if route != tc.expectedRoute {
+				t.Errorf("Unexpected route. Got %+v, expected %+v", route, tc.expectedRoute)
 			}
 		})
 	}
 }
+
+func TestResolveHandlers(t *testing.T) {
+	handlers := []Handler{
+		{Name: "Get", Receiver: "UserHandler", Package: "example.com/app/users"},
+		{Name: "Get", Receiver: "OrderHandler", Package: "example.com/app/orders"},
+		{Name: "Me", Receiver: "UserHandler", Package: "example.com/app/users"},
+		{Name: "Health", Package: "example.com/app"},
+		{Name: "Health", Package: "example.com/app/admin"},
+		{Name: "Version", Package: "example.com/app"},
+	}
+	routes := []model.Route{
+		{Pattern: "/users/:id", Handler: "Get"},
+		{Pattern: "/orders/:id", Handler: "Get", HandlerReceiver: "OrderHandler"},
+		{Pattern: "/me", Handler: "Me"},
+		{Pattern: "/health", Handler: "Health"},
+		{Pattern: "/version", Handler: "Version", HandlerPackage: "main"},
+		{Pattern: "/me/legacy", Handler: "Me", HandlerPackage: "main"},
+		{Pattern: "/admin/health", Handler: "Health", HandlerPackage: "example.com/app/legacy"},
+	}
+
+	ResolveHandlers(routes, handlers)
+	expected := []model.Route{
+		{Pattern: "/users/:id", Handler: "Get"},
+		{Pattern: "/orders/:id", Handler: "Get", HandlerReceiver: "OrderHandler", HandlerPackage: "example.com/app/orders"},
+		{Pattern: "/me", Handler: "Me", HandlerReceiver: "UserHandler", HandlerPackage: "example.com/app/users"},
+		{Pattern: "/health", Handler: "Health"},
+		{Pattern: "/version", Handler: "Version", HandlerPackage: "example.com/app"},
+		// The runtime would have named the receiver of a method
+		{Pattern: "/me/legacy", Handler: "Me", HandlerPackage: "main"},
+		{Pattern: "/admin/health", Handler: "Health", HandlerPackage: "example.com/app/legacy"},
+	}
+	if !reflect.DeepEqual(routes, expected) {
+		t.Errorf("Unexpected routes. Got %+v, expected %+v", routes, expected)
+	}
+	if bound := BoundRoutes(handlers[0], routes); len(bound) != 0 {
+		t.Errorf("Expected the ambiguous route to stay unbound, got %+v", bound)
+	}
+}
+
+func TestSwaggerPath(t *testing.T) {
+	testCases := []struct {
+		pattern  string
+		basePath string
+		expected string
+	}{
+		{pattern: "/users/:id", expected: "/users/{id}"},
+		{pattern: "/static/*filepath", expected: "/static/{filepath}"},
+		{pattern: "/files/*", expected: "/files/{wildcard}"},
+		{pattern: "/flights/:from-:to", expected: "/flights/{from}-{to}"},
+		{pattern: "/users/:id<int>?", expected: "/users/{id}"},
+		{pattern: "/items/{id}", expected: "/items/{id}"},
+		{pattern: "/items/{id:[0-9]+}", expected: "/items/{id}"},
+		{pattern: "/codes/{code:[A-Z]{3}}/{rest}", expected: "/codes/{code}/{rest}"},
+		{pattern: "/files/{path...}", expected: "/files/{path}"},
+		{pattern: "/items/{$}", expected: "/items/"},
+		{pattern: "/api/v1/users/:id", basePath: "/api/v1", expected: "/users/{id}"},
+		{pattern: "/api/v1", basePath: "/api/v1/", expected: "/"},
+		{pattern: "/api/v10/users", basePath: "/api/v1", expected: "/api/v10/users"},
+		{pattern: "/", basePath: "/", expected: "/"},
+	}
+
+	for _, tc := range testCases {
+		if path := SwaggerPath(tc.pattern, tc.basePath); path != tc.expected {
+			t.Errorf("SwaggerPath(%q, %q) = %q, expected %q", tc.pattern, tc.basePath, path, tc.expected)
+		}
+	}
+}
+
+func TestPathParams(t *testing.T) {
+	params := PathParams("/users/{id:[0-9]+}/files/{path...}/{id}/{$}")
+	if !reflect.DeepEqual(params, []string{"id", "path"}) {
+		t.Errorf("Unexpected params %v", params)
+	}
+}
+
+func TestSetRouterPaths(t *testing.T) {
+	routes := []model.Route{{Method: "GET", Path: "/:id", Pattern: "/api/users/:id"}}
+	SetRouterPaths(routes, "/api")
+	if route := FormatRoutes(routes); route != "/users/{id} [get]" {
+		t.Errorf("Unexpected route. Got %+v, expected %+v", route, "/users/{id} [get]")
+	}
+}
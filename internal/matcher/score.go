@@ -0,0 +1,255 @@
+package matcher
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/insectkorea/swagGPT/framework"
+	"github.com/insectkorea/swagGPT/internal/model"
+)
+
+// Candidate is a route a handler may serve.
+type Candidate struct {
+	Route model.Route
+	// Score is the confidence that the handler serves Route, from 0 to 1.
+	Score float64
+	// Reasons explain Score, as in `path has "users"`.
+	Reasons []string
+}
+
+// Weights of the parts of a score: the share of the handler's nouns found in
+// the path, the agreement of its verb with the method, and the share of the
+// path's words the handler names.
+const (
+	nounWeight     = 0.6
+	methodWeight   = 0.25
+	coverageWeight = 0.15
+	// paramWeight is the weight of a noun found only in a path parameter, as
+	// org in {organization_id}, relative to one found in a static segment.
+	paramWeight = 0.5
+)
+
+// verbMethods maps the verbs handler names start with to the methods of the
+// routes they serve.
+var verbMethods = map[string][]string{
+	"list":     {"GET"},
+	"get":      {"GET"},
+	"find":     {"GET"},
+	"fetch":    {"GET"},
+	"show":     {"GET"},
+	"read":     {"GET"},
+	"search":   {"GET"},
+	"create":   {"POST"},
+	"add":      {"POST"},
+	"new":      {"POST"},
+	"post":     {"POST"},
+	"register": {"POST"},
+	"update":   {"PUT", "PATCH"},
+	"edit":     {"PUT", "PATCH"},
+	"put":      {"PUT"},
+	"patch":    {"PATCH"},
+	"set":      {"PUT", "PATCH"},
+	"delete":   {"DELETE"},
+	"remove":   {"DELETE"},
+	"destroy":  {"DELETE"},
+}
+
+// fillerWords are the words of handler names and paths that say nothing about
+// the resource, as in UserHandler or /api/v1/users.
+var fillerWords = map[string]bool{
+	"handler": true, "controller": true, "ctrl": true, "api": true, "http": true,
+	"by": true, "for": true, "of": true, "and": true, "with": true, "the": true,
+	"all": true, "to": true, "from": true, "in": true, "on": true, "id": true,
+}
+
+// RankRoutes returns the routes handler may serve, ranked by decreasing score,
+// with routes of equal score in their original order. The words of its name
+// and receiver type are compared with those of each path, singular and plural
+// forms alike, and the leading verb of its name, as in ListUsers, with the
+// method. Routes sharing no word with the handler are left out.
+func RankRoutes(handler Handler, routes []model.Route) []Candidate {
+	nouns, methods := handlerTerms(handler)
+	if len(nouns) == 0 {
+		return nil
+	}
+
+	var candidates []Candidate
+	for _, route := range routes {
+		if candidate := scoreRoute(nouns, methods, route); candidate.Score > 0 {
+			candidates = append(candidates, candidate)
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].Score > candidates[j].Score
+	})
+	return candidates
+}
+
+// scoreRoute returns route as a candidate for a handler named with nouns,
+// serving methods, scored 0 if the path shares no word with the handler.
+func scoreRoute(nouns []string, methods []string, route model.Route) Candidate {
+	candidate := Candidate{Route: route}
+	static, params := pathTerms(routePattern(route))
+
+	var found float64
+	for _, noun := range nouns {
+		if word, ok := findWord(static, noun); ok {
+			found++
+			candidate.Reasons = append(candidate.Reasons, fmt.Sprintf("path has %q", word))
+		} else if word, ok := findWord(params, noun); ok {
+			found += paramWeight
+			candidate.Reasons = append(candidate.Reasons, fmt.Sprintf("parameter has %q", word))
+		}
+	}
+	if found == 0 {
+		return Candidate{Route: route}
+	}
+
+	methodScore := 0.5
+	if len(methods) > 0 {
+		methodScore = 0
+		if route.Method == framework.MethodAny || slices.Contains(methods, strings.ToUpper(route.Method)) {
+			methodScore = 1
+			candidate.Reasons = append(candidate.Reasons, fmt.Sprintf("verb fits %s", route.Method))
+		} else {
+			candidate.Reasons = append(candidate.Reasons, fmt.Sprintf("verb does not fit %s", route.Method))
+		}
+	}
+
+	var coverage float64
+	if len(static) > 0 {
+		var named int
+		for _, word := range static {
+			if _, ok := findWord(nouns, word); ok {
+				named++
+			}
+		}
+		coverage = float64(named) / float64(len(static))
+		if named < len(static) {
+			candidate.Reasons = append(candidate.Reasons, fmt.Sprintf("names %d of %d path words", named, len(static)))
+		}
+	}
+
+	candidate.Score = nounWeight*found/float64(len(nouns)) + methodWeight*methodScore + coverageWeight*coverage
+	return candidate
+}
+
+// handlerTerms returns the words of the name and receiver type of handler
+// naming the resource, and the methods the leading verb of its name maps to,
+// if any. The receiver names the resource of methods such as
+// UserHandler.Delete, whose name has none.
+func handlerTerms(handler Handler) (nouns []string, methods []string) {
+	for _, word := range splitWords(handler.Name) {
+		if m, ok := verbMethods[word]; ok && methods == nil {
+			methods = m
+			continue
+		}
+		if !fillerWords[word] {
+			nouns = append(nouns, word)
+		}
+	}
+	for _, word := range splitWords(handler.Receiver) {
+		if _, ok := findWord(nouns, word); !ok && !fillerWords[word] {
+			nouns = append(nouns, word)
+		}
+	}
+	return nouns, methods
+}
+
+// pathTerms returns the words of the static segments of a path, and those of
+// its parameters, as in {organization_id} or :id. Version segments such as v1
+// are skipped.
+func pathTerms(path string) (static []string, params []string) {
+	for _, segment := range strings.Split(path, "/") {
+		isParam := strings.HasPrefix(segment, "{") || strings.HasPrefix(segment, ":") || strings.HasPrefix(segment, "*")
+		for _, word := range splitWords(segment) {
+			if fillerWords[word] || isVersion(word) {
+				continue
+			}
+			if isParam {
+				params = append(params, word)
+			} else {
+				static = append(static, word)
+			}
+		}
+	}
+	return static, params
+}
+
+// isVersion reports whether word is an API version, as in v1.
+func isVersion(word string) bool {
+	return len(word) > 1 && word[0] == 'v' && strings.Trim(word[1:], "0123456789") == ""
+}
+
+// splitWords splits s into lowercase words at camelCase and snake_case
+// boundaries and at any other character than a letter or digit, keeping
+// acronyms whole, as in RBFBundleHandler_ListByOrg.
+func splitWords(s string) []string {
+	var words []string
+	var word []rune
+	flush := func() {
+		if len(word) > 0 {
+			words = append(words, strings.ToLower(string(word)))
+			word = word[:0]
+		}
+	}
+
+	runes := []rune(s)
+	for i, r := range runes {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			flush()
+			continue
+		}
+		if i > 0 && len(word) > 0 {
+			prev := runes[i-1]
+			switch {
+			case unicode.IsUpper(r) && unicode.IsLower(prev):
+				flush()
+			// The last capital of an acronym starts the next word, as the
+			// B of RBFBundle
+			case unicode.IsUpper(r) && unicode.IsUpper(prev) && i+1 < len(runes) && unicode.IsLower(runes[i+1]):
+				flush()
+			case unicode.IsDigit(r) != unicode.IsDigit(prev) && !isVersion(strings.ToLower(string(word))+string(r)):
+				flush()
+			}
+		}
+		word = append(word, r)
+	}
+	flush()
+	return words
+}
+
+// findWord returns the first of words that is word, in singular or plural
+// form, or a word it abbreviates or is abbreviated by, as org and
+// organization.
+func findWord(words []string, word string) (string, bool) {
+	word = singular(word)
+	for _, w := range words {
+		s := singular(w)
+		short, long := s, word
+		if len(short) > len(long) {
+			short, long = long, short
+		}
+		if s == word || (len(short) >= 3 && strings.HasPrefix(long, short)) {
+			return w, true
+		}
+	}
+	return "", false
+}
+
+// singular returns the singular form of the English noun word, by the common
+// rules of plural forms.
+func singular(word string) string {
+	switch {
+	case len(word) > 4 && strings.HasSuffix(word, "ies"):
+		return strings.TrimSuffix(word, "ies") + "y"
+	case len(word) > 4 && (strings.HasSuffix(word, "sses") || strings.HasSuffix(word, "xes") || strings.HasSuffix(word, "ches") || strings.HasSuffix(word, "shes")):
+		return strings.TrimSuffix(word, "es")
+	case len(word) > 3 && strings.HasSuffix(word, "s") && !strings.HasSuffix(word, "ss") && !strings.HasSuffix(word, "us"):
+		return strings.TrimSuffix(word, "s")
+	}
+	return word
+}
@@ -0,0 +1,104 @@
+package matcher
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/insectkorea/swagGPT/internal/model"
+)
+
+func TestSplitWords(t *testing.T) {
+	testCases := map[string][]string{
+		"RBFBundleHandler_ListByOrg": {"rbf", "bundle", "handler", "list", "by", "org"},
+		"getUserByID":                {"get", "user", "by", "id"},
+		"{organization_id}":          {"organization", "id"},
+		"oauth2-callback":            {"oauth", "2", "callback"},
+		"v1":                         {"v1"},
+	}
+	for s, expected := range testCases {
+		if words := splitWords(s); !reflect.DeepEqual(words, expected) {
+			t.Errorf("splitWords(%q) = %v, expected %v", s, words, expected)
+		}
+	}
+}
+
+func TestSingular(t *testing.T) {
+	testCases := map[string]string{
+		"users":      "user",
+		"categories": "category",
+		"addresses":  "address",
+		"boxes":      "box",
+		"status":     "status",
+		"access":     "access",
+		"user":       "user",
+	}
+	for word, expected := range testCases {
+		if s := singular(word); s != expected {
+			t.Errorf("singular(%q) = %q, expected %q", word, s, expected)
+		}
+	}
+}
+
+func TestRankRoutes(t *testing.T) {
+	routes := []model.Route{
+		{Method: "GET", Pattern: "/api/v1/categories"},
+		{Method: "GET", Pattern: "/api/v1/categories/:id"},
+		{Method: "PUT", Pattern: "/api/v1/categories/:id"},
+		{Method: "DELETE", Pattern: "/api/v1/categories/:id"},
+		{Method: "GET", Pattern: "/api/v1/orgs/:org_id/members"},
+		{Method: "GET", Pattern: "/health"},
+	}
+
+	testCases := []struct {
+		handler  Handler
+		expected []string
+	}{
+		{handler: Handler{Name: "UpdateCategory"}, expected: []string{"PUT /api/v1/categories/:id", "GET /api/v1/categories", "GET /api/v1/categories/:id", "DELETE /api/v1/categories/:id"}},
+		{handler: Handler{Name: "CategoryHandler_Delete"}, expected: []string{"DELETE /api/v1/categories/:id", "GET /api/v1/categories", "GET /api/v1/categories/:id", "PUT /api/v1/categories/:id"}},
+		{handler: Handler{Name: "Delete", Receiver: "CategoryHandler"}, expected: []string{"DELETE /api/v1/categories/:id", "GET /api/v1/categories", "GET /api/v1/categories/:id", "PUT /api/v1/categories/:id"}},
+		{handler: Handler{Name: "Get", Receiver: "MemberController"}, expected: []string{"GET /api/v1/orgs/:org_id/members"}},
+		{handler: Handler{Name: "ListCategories", Receiver: "CategoryHandler"}, expected: []string{"GET /api/v1/categories", "GET /api/v1/categories/:id", "PUT /api/v1/categories/:id", "DELETE /api/v1/categories/:id"}},
+		{handler: Handler{Name: "ListOrganizationMembers"}, expected: []string{"GET /api/v1/orgs/:org_id/members"}},
+		{handler: Handler{Name: "Status"}, expected: nil},
+		{handler: Handler{Name: "List"}, expected: nil},
+		{handler: Handler{Name: "List", Receiver: "Handler"}, expected: nil},
+	}
+	for _, tc := range testCases {
+		var got []string
+		for _, candidate := range RankRoutes(tc.handler, routes) {
+			got = append(got, candidate.Route.Method+" "+candidate.Route.Pattern)
+		}
+		if !reflect.DeepEqual(got, tc.expected) {
+			t.Errorf("RankRoutes(%+v) = %v, expected %v", tc.handler, got, tc.expected)
+		}
+	}
+}
+
+func TestRankRoutesScores(t *testing.T) {
+	routes := []model.Route{
+		{Method: "GET", Pattern: "/users/{id}"},
+		{Method: "GET", Pattern: "/users/{id}/orders"},
+	}
+	candidates := RankRoutes(Handler{Name: "GetUser"}, routes)
+	if len(candidates) != 2 {
+		t.Fatalf("Expected 2 candidates, got %v", candidates)
+	}
+	if candidates[0].Score != 1 {
+		t.Errorf("Expected a full score for an exact match, got %v", candidates[0].Score)
+	}
+	if candidates[1].Score >= candidates[0].Score || candidates[1].Score <= 0 {
+		t.Errorf("Expected a lower positive score for a partial match, got %v", candidates[1].Score)
+	}
+}
+
+func TestRankRoutesReasons(t *testing.T) {
+	routes := []model.Route{{Method: "DELETE", Pattern: "/orgs/{org_id}/users"}}
+	candidates := RankRoutes(Handler{Name: "GetOrganizationUser"}, routes)
+	if len(candidates) != 1 {
+		t.Fatalf("Expected 1 candidate, got %v", candidates)
+	}
+	expected := []string{`path has "orgs"`, `path has "users"`, "verb does not fit DELETE"}
+	if !reflect.DeepEqual(candidates[0].Reasons, expected) {
+		t.Errorf("Expected reasons %v, got %v", expected, candidates[0].Reasons)
+	}
+}
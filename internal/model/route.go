@@ -2,6 +2,34 @@ package model
 
 type Route struct {
 	Method  string
+	Host    string
 	Path    string
 	Pattern string
+	// RouterPath is the path the route is documented with in its @Router
+	// line: Pattern relative to the @BasePath of the API, with path
+	// parameters written {name}.
+	RouterPath string
+	// Params lists the names of the path wildcards, e.g. "id" for "/items/{id}".
+	Params []string
+	// Handler is the name of the function or method the route is registered
+	// with, or of the factory returning it, if known.
+	Handler string
+	// HandlerPackage is the import path of the package declaring Handler, if
+	// it is qualified as in handlers.GetUser.
+	HandlerPackage string
+	// HandlerReceiver is the receiver type name of the method Handler, if it
+	// is known, as in h.GetUser where h := &UserHandler{}.
+	HandlerReceiver string
+	// Position is the file:line:column the route is registered at, if known.
+	Position string
+	// Middleware lists the functions guarding the route, in the order they
+	// apply, qualified by import path as in "example.com/app/auth.RequireJWT".
+	Middleware []string
+	// Security lists the security schemes of the route, as in "BearerAuth",
+	// and Failures the @Failure annotations its middleware adds, as in
+	// `401 {object} api.Error "Unauthorized"`.
+	Security []string
+	Failures []string
+	// Tags lists the @Tags of the route's handler, if they are pinned.
+	Tags []string
 }
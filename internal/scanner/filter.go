@@ -0,0 +1,197 @@
+package scanner
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// IgnoreFile is the name of the file listing the paths to skip, read from the
+// scanned directory.
+const IgnoreFile = ".swaggptignore"
+
+// defaultExcludes are the paths skipped unless a later pattern re-includes
+// them: vendored and test packages and test files.
+var defaultExcludes = []string{"vendor/", "testdata/", "*_test.go"}
+
+// generatedComment matches the comment marking generated Go files.
+var generatedComment = regexp.MustCompile(`^// Code generated .* DO NOT EDIT\.$`)
+
+// Filter selects the files to scan.
+//
+// Exclude patterns follow .gitignore: a pattern without a slash matches a
+// file or directory name at any depth, a pattern with a slash matches a path
+// relative to the scanned directory, a trailing slash only matches
+// directories, ** matches any number of directories, and a leading ! includes
+// the paths matched by the previous patterns again. The last matching pattern
+// wins. Generated files are skipped unless a negated pattern matches them.
+type Filter struct {
+	// Include lists the patterns a file must match one of, if any.
+	Include []string
+	// Exclude lists the patterns of the files to skip, after the default ones.
+	Exclude []string
+}
+
+// LoadFilter returns a filter for dir with the given include and exclude
+// patterns, preceded by those of the ignore file of dir if there is one.
+func LoadFilter(dir string, include, exclude []string) (*Filter, error) {
+	filter := &Filter{Include: include}
+
+	file, err := os.Open(filepath.Join(dir, IgnoreFile))
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to read %s: %v", IgnoreFile, err)
+	}
+	if err == nil {
+		defer file.Close()
+		lines := bufio.NewScanner(file)
+		for lines.Scan() {
+			line := strings.TrimSpace(lines.Text())
+			if line != "" && !strings.HasPrefix(line, "#") {
+				filter.Exclude = append(filter.Exclude, line)
+			}
+		}
+		if err := lines.Err(); err != nil {
+			return nil, fmt.Errorf("failed to read %s: %v", IgnoreFile, err)
+		}
+	}
+
+	filter.Exclude = append(filter.Exclude, exclude...)
+	return filter, nil
+}
+
+// Narrows reports whether f has patterns of its own, from the ignore file or
+// given with include and exclude, so that it may skip files holding handlers.
+func (f *Filter) Narrows() bool {
+	return f != nil && (len(f.Include) > 0 || len(f.Exclude) > 0)
+}
+
+// patterns returns the exclude patterns in the order they apply.
+func (f *Filter) patterns() []string {
+	if f == nil {
+		return defaultExcludes
+	}
+	return append(append([]string(nil), defaultExcludes...), f.Exclude...)
+}
+
+// skipDir reports whether the directory at rel, relative to the scanned
+// directory, and everything under it can be skipped.
+func (f *Filter) skipDir(rel string) bool {
+	patterns := f.patterns()
+	for _, pattern := range patterns {
+		// A negated pattern may include some of its files again
+		if strings.HasPrefix(pattern, "!") {
+			return false
+		}
+	}
+	return excluded(patterns, rel, true, false)
+}
+
+// match reports whether the file at rel, relative to the scanned directory,
+// should be scanned. filename is its path, read to tell generated files.
+func (f *Filter) match(rel string, filename string) (bool, error) {
+	if f != nil && len(f.Include) > 0 && !slices.ContainsFunc(f.Include, func(pattern string) bool {
+		return matchPath(pattern, rel, false)
+	}) {
+		return false, nil
+	}
+
+	generated, err := isGenerated(filename)
+	if err != nil {
+		return false, err
+	}
+	return !excluded(f.patterns(), rel, false, generated), nil
+}
+
+// excluded applies patterns to the path rel and to the directories it is in.
+func excluded(patterns []string, rel string, isDir bool, generated bool) bool {
+	skip := generated
+	for _, pattern := range patterns {
+		negated := strings.HasPrefix(pattern, "!")
+		pattern = strings.TrimPrefix(pattern, "!")
+		if matchPath(pattern, rel, isDir) {
+			skip = !negated
+		}
+	}
+	return skip
+}
+
+// matchPath reports whether pattern matches the path rel, which is a directory
+// if isDir is set, or one of the directories it is in, so that a directory
+// pattern such as handlers/ matches everything under it.
+func matchPath(pattern string, rel string, isDir bool) bool {
+	elems := strings.Split(rel, "/")
+	for i := range elems {
+		dir := i < len(elems)-1 || isDir
+		if matchPattern(pattern, strings.Join(elems[:i+1], "/"), dir) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchPattern reports whether pattern matches the path rel, which is a
+// directory if isDir is set.
+func matchPattern(pattern string, rel string, isDir bool) bool {
+	if strings.HasSuffix(pattern, "/") {
+		if !isDir {
+			return false
+		}
+		pattern = strings.TrimSuffix(pattern, "/")
+	}
+	if !strings.Contains(pattern, "/") {
+		ok, _ := path.Match(pattern, path.Base(rel))
+		return ok
+	}
+	return matchSegments(strings.Split(strings.TrimPrefix(pattern, "/"), "/"), strings.Split(rel, "/"))
+}
+
+// matchSegments matches path segments against pattern segments, where **
+// matches any number of segments.
+func matchSegments(pattern, elems []string) bool {
+	if len(pattern) == 0 {
+		return len(elems) == 0
+	}
+	if pattern[0] == "**" {
+		for i := 0; i <= len(elems); i++ {
+			if matchSegments(pattern[1:], elems[i:]) {
+				return true
+			}
+		}
+		return false
+	}
+	if len(elems) == 0 {
+		return false
+	}
+	if ok, _ := path.Match(pattern[0], elems[0]); !ok {
+		return false
+	}
+	return matchSegments(pattern[1:], elems[1:])
+}
+
+// isGenerated reports whether the Go file at filename is generated, following
+// https://go.dev/s/generatedcode: a line matching generatedComment appears
+// before the package clause.
+func isGenerated(filename string) (bool, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return false, err
+	}
+	defer file.Close()
+
+	lines := bufio.NewScanner(file)
+	for lines.Scan() {
+		line := strings.TrimSpace(lines.Text())
+		if generatedComment.MatchString(line) {
+			return true, nil
+		}
+		if strings.HasPrefix(line, "package ") {
+			return false, nil
+		}
+	}
+	return false, lines.Err()
+}
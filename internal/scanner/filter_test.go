@@ -0,0 +1,130 @@
+package scanner
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func writeTree(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("Failed to create directory: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+	return dir
+}
+
+func relFiles(t *testing.T, dir string, files []string) []string {
+	t.Helper()
+	var rels []string
+	for _, file := range files {
+		rel, err := filepath.Rel(dir, file)
+		if err != nil {
+			t.Fatalf("Failed to make %s relative: %v", file, err)
+		}
+		rels = append(rels, filepath.ToSlash(rel))
+	}
+	return rels
+}
+
+func TestScanDirFilters(t *testing.T) {
+	tree := map[string]string{
+		"main.go":                        "package main\n",
+		"handlers/users.go":              "package handlers\n",
+		"handlers/users_test.go":         "package handlers\n",
+		"handlers/mocks/users.go":        "package mocks\n",
+		"internal/gen/api.go":            "// Code generated by oapi-codegen. DO NOT EDIT.\n\npackage gen\n",
+		"internal/testdata/example.go":   "package example\n",
+		"vendor/github.com/x/y/y.go":     "package y\n",
+		"docs/README.md":                 "# docs\n",
+		"internal/gen/doc.go":            "// Package gen holds generated code.\npackage gen\n",
+		"legacy/old.go":                  "package legacy\n",
+		"legacy/keep.go":                 "package legacy\n",
+		"internal/testdata/fixtures.txt": "",
+	}
+
+	testCases := []struct {
+		name     string
+		ignore   string
+		include  []string
+		exclude  []string
+		expected []string
+	}{
+		{
+			name:     "defaults",
+			expected: []string{"handlers/mocks/users.go", "handlers/users.go", "internal/gen/doc.go", "legacy/keep.go", "legacy/old.go", "main.go"},
+		},
+		{
+			name:     "ignore file",
+			ignore:   "# old code\nlegacy/\n!legacy/keep.go\nmocks\n",
+			expected: []string{"handlers/users.go", "internal/gen/doc.go", "legacy/keep.go", "main.go"},
+		},
+		{
+			name:     "include",
+			include:  []string{"handlers/**"},
+			expected: []string{"handlers/mocks/users.go", "handlers/users.go"},
+		},
+		{
+			name:     "include directory",
+			include:  []string{"internal/gen"},
+			expected: []string{"internal/gen/doc.go"},
+		},
+		{
+			name:     "include directory with slash",
+			include:  []string{"internal/gen/"},
+			expected: []string{"internal/gen/doc.go"},
+		},
+		{
+			name:     "include directory at any depth",
+			include:  []string{"mocks"},
+			expected: []string{"handlers/mocks/users.go"},
+		},
+		{
+			name:     "exclude overrides defaults",
+			exclude:  []string{"!*_test.go", "!internal/gen/*.go", "legacy/*.go", "main.go"},
+			expected: []string{"handlers/mocks/users.go", "handlers/users.go", "handlers/users_test.go", "internal/gen/api.go", "internal/gen/doc.go"},
+		},
+		{
+			name:     "testdata included again",
+			exclude:  []string{"!testdata/"},
+			include:  []string{"internal/**/*.go"},
+			expected: []string{"internal/gen/doc.go", "internal/testdata/example.go"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			files := map[string]string{}
+			for name, content := range tree {
+				files[name] = content
+			}
+			if tc.ignore != "" {
+				files[IgnoreFile] = tc.ignore
+			}
+			dir := writeTree(t, files)
+
+			filter, err := LoadFilter(dir, tc.include, tc.exclude)
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			scanned, err := ScanDir(dir, filter)
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if got := relFiles(t, dir, scanned); !reflect.DeepEqual(got, tc.expected) {
+				t.Fatalf("Expected files %v, got %v", tc.expected, got)
+			}
+			if narrows := tc.ignore != "" || tc.include != nil || tc.exclude != nil; filter.Narrows() != narrows {
+				t.Fatalf("Expected Narrows() to be %v", narrows)
+			}
+		})
+	}
+}
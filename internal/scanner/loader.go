@@ -0,0 +1,162 @@
+package scanner
+
+import (
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+)
+
+const loadMode = packages.NeedName | packages.NeedFiles | packages.NeedCompiledGoFiles |
+	packages.NeedSyntax | packages.NeedTypes | packages.NeedTypesInfo
+
+// loadFile loads the package containing filename with type information and
+// returns the syntax tree of the file together with the package's type info.
+// Files that are not part of any package in the current build configuration
+// are type-checked on their own.
+func loadFile(filename string) (*ast.File, *token.FileSet, *types.Info, error) {
+	absPath, err := filepath.Abs(filename)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	target, err := os.Stat(absPath)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	fset := token.NewFileSet()
+	cfg := &packages.Config{
+		Mode: loadMode,
+		Dir:  filepath.Dir(absPath),
+		Env:  loaderEnv(),
+		Fset: fset,
+	}
+	pkgs, err := packages.Load(cfg, "file="+absPath)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to load package of %s: %v", filename, err)
+	}
+
+	for _, pkg := range pkgs {
+		for _, file := range pkg.Syntax {
+			info, err := os.Stat(fset.File(file.Pos()).Name())
+			if err != nil || !os.SameFile(info, target) {
+				continue
+			}
+			if err := parseError(pkg, fset.File(file.Pos()).Name()); err != nil {
+				return nil, nil, nil, err
+			}
+			return file, fset, pkg.TypesInfo, nil
+		}
+	}
+
+	return checkFile(absPath)
+}
+
+// LoadModule loads the packages of the module containing dir with type
+// information. Packages with type errors, such as unresolved imports, are
+// still returned with the information that could be recovered.
+func LoadModule(dir string) ([]*packages.Package, error) {
+	root, err := moduleRoot(dir)
+	if err != nil {
+		return nil, err
+	}
+	cfg := &packages.Config{
+		Mode: loadMode,
+		Dir:  root,
+		Env:  loaderEnv(),
+	}
+	pkgs, err := packages.Load(cfg, "./...")
+	if err != nil {
+		return nil, fmt.Errorf("failed to load packages of %s: %v", root, err)
+	}
+	return pkgs, nil
+}
+
+// moduleRoot returns the directory of the go.mod file dir belongs to.
+func moduleRoot(dir string) (string, error) {
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+	for d := absDir; ; d = filepath.Dir(d) {
+		if _, err := os.Stat(filepath.Join(d, "go.mod")); err == nil {
+			return d, nil
+		}
+		if filepath.Dir(d) == d {
+			return "", fmt.Errorf("no go.mod found in %s or its parents", dir)
+		}
+	}
+}
+
+// checkFile parses and type-checks a single file in isolation with CheckFile.
+func checkFile(filename string) (*ast.File, *token.FileSet, *types.Info, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, filename, nil, parser.ParseComments)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return file, fset, CheckFile(fset, file), nil
+}
+
+// CheckFile type-checks file on its own and returns the type information
+// recovered. Its imports are not resolved, but the package names still refer
+// to their import paths, and its own declarations and local variables are
+// resolved.
+func CheckFile(fset *token.FileSet, file *ast.File) *types.Info {
+	info := &types.Info{
+		Types:     map[ast.Expr]types.TypeAndValue{},
+		Defs:      map[*ast.Ident]types.Object{},
+		Uses:      map[*ast.Ident]types.Object{},
+		Implicits: map[ast.Node]types.Object{},
+	}
+	conf := types.Config{
+		Importer: unresolvedImporter{},
+		Error:    func(error) {},
+	}
+	// Type errors are expected since no import is available.
+	_, _ = conf.Check(file.Name.Name, fset, []*ast.File{file}, info)
+	return info
+}
+
+type unresolvedImporter struct{}
+
+func (unresolvedImporter) Import(path string) (*types.Package, error) {
+	return nil, errors.New("imports are not resolved")
+}
+
+// parseError returns the first syntax error reported for filename, if any.
+func parseError(pkg *packages.Package, filename string) error {
+	for _, err := range pkg.Errors {
+		if err.Kind == packages.ParseError && strings.HasPrefix(err.Pos, filename) {
+			return err
+		}
+	}
+	return nil
+}
+
+// loaderEnv returns the environment for the go command used to load packages.
+// -mod=mod is dropped from GOFLAGS so that loading never rewrites the go.mod
+// of the scanned module.
+func loaderEnv() []string {
+	env := os.Environ()
+	for i, kv := range env {
+		if !strings.HasPrefix(kv, "GOFLAGS=") {
+			continue
+		}
+		var flags []string
+		for _, flag := range strings.Fields(strings.TrimPrefix(kv, "GOFLAGS=")) {
+			if flag != "-mod=mod" {
+				flags = append(flags, flag)
+			}
+		}
+		env[i] = "GOFLAGS=" + strings.Join(flags, " ")
+	}
+	return env
+}
@@ -4,20 +4,64 @@ import (
 	"go/ast"
 	"go/parser"
 	"go/token"
+	"go/types"
 	"os"
 	"path/filepath"
+	"strings"
 
+	"github.com/insectkorea/swagGPT/framework"
 	"github.com/sirupsen/logrus"
 )
 
-// ScanDir scans the given directory for Go files and returns a list of file paths.
-func ScanDir(dir string) ([]string, error) {
+// Handler is a handler function found in a file.
+type Handler struct {
+	Decl *ast.FuncDecl
+	// Framework is the framework the handler is written for.
+	Framework framework.Framework
+	// Package is the import path of the package declaring the handler.
+	Package string
+}
+
+// Options selects the handlers ParseFile returns.
+type Options struct {
+	// Unexported includes unexported handlers whose name is in Referenced,
+	// as in r.GET("/x", h.list).
+	Unexported bool
+	// Referenced holds the names of the functions and methods routes are
+	// registered with.
+	Referenced map[string]bool
+	// Receiver only includes the methods of the named receiver type, such as
+	// UserHandler or *UserHandler.
+	Receiver string
+}
+
+// ScanDir scans the given directory for Go files selected by filter and
+// returns a list of file paths. A nil filter only skips the default paths.
+func ScanDir(dir string, filter *Filter) ([]string, error) {
 	var files []string
 	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
 		if err != nil {
 			return err
 		}
-		if !info.IsDir() && filepath.Ext(path) == ".go" {
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		if info.IsDir() {
+			if rel != "." && filter.skipDir(rel) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if filepath.Ext(path) != ".go" {
+			return nil
+		}
+		ok, err := filter.match(rel, path)
+		if err != nil {
+			return err
+		}
+		if ok {
 			files = append(files, path)
 		}
 		return nil
@@ -25,19 +69,44 @@ func ScanDir(dir string) ([]string, error) {
 	return files, err
 }
 
-// ParseFile parses the Go file and returns a list of handler functions.
-func ParseFile(filename string) ([]*ast.FuncDecl, *token.FileSet, error) {
-	fset := token.NewFileSet()
-	node, err := parser.ParseFile(fset, filename, nil, parser.ParseComments)
+// FindBasePath returns the path of the first @BasePath annotation in the
+// comments of files, which declares the base path of the API in swag's general
+// API info, or an empty string if there is none.
+func FindBasePath(files []string) string {
+	for _, file := range files {
+		node, err := parser.ParseFile(token.NewFileSet(), file, nil, parser.ParseComments)
+		if err != nil {
+			continue
+		}
+		for _, group := range node.Comments {
+			for _, comment := range group.List {
+				fields := strings.Fields(strings.TrimPrefix(comment.Text, "//"))
+				if len(fields) == 2 && fields[0] == "@BasePath" {
+					return fields[1]
+				}
+			}
+		}
+	}
+	return ""
+}
+
+// ParseFile parses the Go file and returns a list of handler functions. A nil
+// opts returns every exported handler.
+func ParseFile(filename string, opts *Options) ([]Handler, *token.FileSet, error) {
+	node, fset, info, err := loadFile(filename)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	var handlers []*ast.FuncDecl
+	var handlers []Handler
 	for _, f := range node.Decls {
-		if fn, isFn := f.(*ast.FuncDecl); isFn {
-			if fn.Name.IsExported() && (isGinContext(fn) || isEchoContext(fn)) {
-				handlers = append(handlers, fn)
+		if fn, isFn := f.(*ast.FuncDecl); isFn && opts.selects(fn) {
+			// Wrappers adapt handlers at registration; they are not endpoints
+			if framework.IsWrapper(fn, info) {
+				continue
+			}
+			if fw := framework.HandlerFramework(fn.Type, info); fw != nil {
+				handlers = append(handlers, Handler{Decl: fn, Framework: fw, Package: packagePath(fn, info)})
 			}
 		}
 	}
@@ -45,36 +114,63 @@ func ParseFile(filename string) ([]*ast.FuncDecl, *token.FileSet, error) {
 	return handlers, fset, nil
 }
 
-func isGinContext(fn *ast.FuncDecl) bool {
-	if fn.Type.Params.NumFields() != 1 {
-		return false
+// packagePath returns the import path of the package declaring fn.
+func packagePath(fn *ast.FuncDecl, info *types.Info) string {
+	if obj := info.Defs[fn.Name]; obj != nil && obj.Pkg() != nil {
+		return obj.Pkg().Path()
 	}
-	paramType := fn.Type.Params.List[0].Type
+	return ""
+}
 
-	if starExpr, ok := paramType.(*ast.StarExpr); ok {
-		if selectorExpr, ok := starExpr.X.(*ast.SelectorExpr); ok {
-			if selectorExpr.Sel.Name == "Context" {
-				if ident, ok := selectorExpr.X.(*ast.Ident); ok {
-					return ident.Name == "gin"
-				}
-			}
-		}
+// selects reports whether fn is selected by opts, not considering its
+// signature.
+func (opts *Options) selects(fn *ast.FuncDecl) bool {
+	if opts == nil {
+		return fn.Name.IsExported()
+	}
+	if !fn.Name.IsExported() && !(opts.Unexported && opts.Referenced[fn.Name.Name]) {
+		return false
 	}
-	return false
+	return opts.Receiver == "" || ReceiverTypeName(fn) == strings.TrimPrefix(opts.Receiver, "*")
 }
 
-func isEchoContext(fn *ast.FuncDecl) bool {
-	if fn.Type.Params.NumFields() != 1 {
-		return false
+// ReceiverTypeName returns the name of the receiver type of fn, without any
+// pointer or type parameters, or an empty string for plain functions.
+func ReceiverTypeName(fn *ast.FuncDecl) string {
+	if fn.Recv == nil || len(fn.Recv.List) == 0 {
+		return ""
+	}
+	recvType := fn.Recv.List[0].Type
+	if star, ok := recvType.(*ast.StarExpr); ok {
+		recvType = star.X
+	}
+	switch x := recvType.(type) {
+	case *ast.IndexExpr:
+		recvType = x.X
+	case *ast.IndexListExpr:
+		recvType = x.X
 	}
-	paramType := fn.Type.Params.List[0].Type
+	if ident, ok := recvType.(*ast.Ident); ok {
+		return ident.Name
+	}
+	return ""
+}
 
-	if selectorExpr, ok := paramType.(*ast.SelectorExpr); ok {
-		if selectorExpr.Sel.Name == "Context" {
-			if ident, ok := selectorExpr.X.(*ast.Ident); ok {
-				return ident.Name == "echo"
-			}
+// HandlerClosure returns the function literal returned at the end of a handler
+// factory such as `func ListUsers(svc UserService) gin.HandlerFunc`, or nil if
+// fn does not return a closure.
+func HandlerClosure(fn *ast.FuncDecl) *ast.FuncLit {
+	if fn.Body == nil || fn.Type.Results.NumFields() != 1 {
+		return nil
+	}
+	for i := len(fn.Body.List) - 1; i >= 0; i-- {
+		returnStmt, ok := fn.Body.List[i].(*ast.ReturnStmt)
+		if !ok || len(returnStmt.Results) != 1 {
+			continue
+		}
+		if closure, ok := returnStmt.Results[0].(*ast.FuncLit); ok {
+			return closure
 		}
 	}
-	return false
+	return nil
 }
@@ -1,13 +1,17 @@
 package scanner
 
 import (
+	"os"
 	"path/filepath"
+	"reflect"
 	"testing"
+
+	"github.com/insectkorea/swagGPT/framework"
 )
 
 func TestScanDir(t *testing.T) {
 	dir := "testdata"
-	files, err := ScanDir(dir)
+	files, err := ScanDir(dir, nil)
 	if err != nil {
 		t.Fatalf("Expected no error, got %v", err)
 	}
@@ -19,8 +23,29 @@ func TestScanDir(t *testing.T) {
 	}
 }
 
+func TestFindBasePath(t *testing.T) {
+	dir := t.TempDir()
+	files := []string{filepath.Join(dir, "handlers.go"), filepath.Join(dir, "main.go")}
+	contents := []string{
+		"package main\n\n// GetUser godoc\n// @Router /users/{id} [get]\nfunc GetUser() {}\n",
+		"package main\n\n// @title Users API\n// @BasePath /api/v1\nfunc main() {}\n",
+	}
+	for i, file := range files {
+		if err := os.WriteFile(file, []byte(contents[i]), 0644); err != nil {
+			t.Fatalf("Failed to write file: %v", err)
+		}
+	}
+
+	if basePath := FindBasePath(files); basePath != "/api/v1" {
+		t.Fatalf("Expected /api/v1, got %q", basePath)
+	}
+	if basePath := FindBasePath(files[:1]); basePath != "" {
+		t.Fatalf("Expected no base path, got %q", basePath)
+	}
+}
+
 func TestParseFile(t *testing.T) {
-	handlers, _, err := ParseFile("testdata/example.go")
+	handlers, _, err := ParseFile("testdata/example.go", nil)
 	if err != nil {
 		t.Fatalf("Expected no error, got %v", err)
 	}
@@ -29,8 +54,147 @@ func TestParseFile(t *testing.T) {
 	}
 	expectedHandlers := []string{"Helloworld", "EchoHandler"}
 	for i, handler := range handlers {
-		if handler.Name.Name != expectedHandlers[i] {
-			t.Fatalf("Expected handler %s, got %s", expectedHandlers[i], handler.Name.Name)
+		if handler.Decl.Name.Name != expectedHandlers[i] {
+			t.Fatalf("Expected handler %s, got %s", expectedHandlers[i], handler.Decl.Name.Name)
+		}
+	}
+}
+
+func TestParseFileResolvesImportAliases(t *testing.T) {
+	testCases := []struct {
+		filename         string
+		expectedHandlers []string
+	}{
+		{filename: "testdata/example_alias.go", expectedHandlers: []string{"AliasedGinHandler", "AliasedEchoHandler"}},
+		{filename: "testdata/vendored/vendored.go", expectedHandlers: nil},
+		{filename: "testdata/ignored.go", expectedHandlers: []string{"IgnoredHandler"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.filename, func(t *testing.T) {
+			handlers, _, err := ParseFile(tc.filename, nil)
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if len(handlers) != len(tc.expectedHandlers) {
+				t.Fatalf("Expected %d handler functions, got %d", len(tc.expectedHandlers), len(handlers))
+			}
+			for i, handler := range handlers {
+				if handler.Decl.Name.Name != tc.expectedHandlers[i] {
+					t.Fatalf("Expected handler %s, got %s", tc.expectedHandlers[i], handler.Decl.Name.Name)
+				}
+			}
+		})
+	}
+}
+
+func TestParseFileHandlerFactories(t *testing.T) {
+	handlers, _, err := ParseFile("testdata/example_factory.go", nil)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	expectedHandlers := []string{"ListUsers", "CountUsers", "EchoListUsers"}
+	if len(handlers) != len(expectedHandlers) {
+		t.Fatalf("Expected %d handler functions, got %d", len(expectedHandlers), len(handlers))
+	}
+	for i, handler := range handlers {
+		if handler.Decl.Name.Name != expectedHandlers[i] {
+			t.Fatalf("Expected handler %s, got %s", expectedHandlers[i], handler.Decl.Name.Name)
 		}
+		if HandlerClosure(handler.Decl) == nil {
+			t.Fatalf("Expected closure returned by %s, got none", handler.Decl.Name.Name)
+		}
+	}
+}
+
+func TestParseFileHTTPHandlers(t *testing.T) {
+	handlers, _, err := ParseFile("testdata/example_http.go", nil)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	expectedHandlers := []string{"GetItem", "ItemsHandler"}
+	if len(handlers) != len(expectedHandlers) {
+		t.Fatalf("Expected %d handler functions, got %d", len(expectedHandlers), len(handlers))
+	}
+	for i, handler := range handlers {
+		if handler.Decl.Name.Name != expectedHandlers[i] {
+			t.Fatalf("Expected handler %s, got %s", expectedHandlers[i], handler.Decl.Name.Name)
+		}
+	}
+}
+
+func TestParseFileFrameworks(t *testing.T) {
+	testCases := []struct {
+		filename           string
+		expectedFrameworks []string
+	}{
+		{filename: "testdata/example.go", expectedFrameworks: []string{"gin", "echo"}},
+		{filename: "testdata/example_factory.go", expectedFrameworks: []string{"gin", "gin", "echo"}},
+		{filename: "testdata/example_http.go", expectedFrameworks: []string{"net/http", "net/http"}},
+		{filename: "testdata/example_fiber.go", expectedFrameworks: []string{"fiber", "fiber"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.filename, func(t *testing.T) {
+			handlers, _, err := ParseFile(tc.filename, nil)
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if len(handlers) != len(tc.expectedFrameworks) {
+				t.Fatalf("Expected %d handler functions, got %d", len(tc.expectedFrameworks), len(handlers))
+			}
+			for i, handler := range handlers {
+				if handler.Framework.Name() != tc.expectedFrameworks[i] {
+					t.Fatalf("Expected framework %s for %s, got %s", tc.expectedFrameworks[i], handler.Decl.Name.Name, handler.Framework.Name())
+				}
+			}
+		})
+	}
+}
+
+func TestParseFileCustomSignatures(t *testing.T) {
+	t.Cleanup(framework.Reset)
+	framework.Register(framework.NewSignature("appctx", []string{"*example.com/app/appctx.Context"}, []string{"any", "error"}, ""))
+	framework.RegisterWrapper("github.com/insectkorea/swagGPT/internal/scanner/testdata.Wrap")
+
+	handlers, _, err := ParseFile("testdata/example_custom.go", nil)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(handlers) != 1 {
+		t.Fatalf("Expected 1 handler function, got %d", len(handlers))
+	}
+	if handlers[0].Decl.Name.Name != "GetOrder" || handlers[0].Framework.Name() != "appctx" {
+		t.Fatalf("Expected GetOrder as an appctx handler, got %s (%s)", handlers[0].Decl.Name.Name, handlers[0].Framework.Name())
+	}
+}
+
+func TestParseFileOptions(t *testing.T) {
+	testCases := []struct {
+		name          string
+		opts          *Options
+		expectedNames []string
+	}{
+		{name: "default", opts: nil, expectedNames: []string{"Get", "Get", "Health"}},
+		{name: "unexported", opts: &Options{Unexported: true, Referenced: map[string]bool{"list": true}}, expectedNames: []string{"Get", "list", "Get", "Health"}},
+		{name: "unreferenced", opts: &Options{Unexported: false, Referenced: map[string]bool{"list": true}}, expectedNames: []string{"Get", "Get", "Health"}},
+		{name: "receiver", opts: &Options{Receiver: "*UserHandler"}, expectedNames: []string{"Get"}},
+		{name: "unexported receiver", opts: &Options{Unexported: true, Referenced: map[string]bool{"list": true}, Receiver: "UserHandler"}, expectedNames: []string{"Get", "list"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			handlers, _, err := ParseFile("testdata/example_methods.go", tc.opts)
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			var names []string
+			for _, handler := range handlers {
+				names = append(names, handler.Decl.Name.Name)
+			}
+			if !reflect.DeepEqual(names, tc.expectedNames) {
+				t.Fatalf("Expected handlers %v, got %v", tc.expectedNames, names)
+			}
+		})
 	}
 }
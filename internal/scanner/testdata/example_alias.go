@@ -0,0 +1,18 @@
+package example
+
+import (
+	"net/http"
+
+	ginpkg "github.com/gin-gonic/gin"
+	e "github.com/labstack/echo/v4"
+)
+
+// AliasedGinHandler handler function
+func AliasedGinHandler(c *ginpkg.Context) {
+	c.JSON(http.StatusOK, "aliased gin")
+}
+
+// AliasedEchoHandler handler function
+func AliasedEchoHandler(c e.Context) error {
+	return c.String(http.StatusOK, "aliased echo")
+}
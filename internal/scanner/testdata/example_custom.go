@@ -0,0 +1,25 @@
+package example
+
+import (
+	"net/http"
+
+	"example.com/app/appctx"
+	"github.com/gin-gonic/gin"
+)
+
+// GetOrder handler function returning the response body
+func GetOrder(ctx *appctx.Context) (any, error) {
+	return map[string]string{"id": ctx.Param("id")}, nil
+}
+
+// Wrap adapts an appctx handler to Gin
+func Wrap(h func(ctx *appctx.Context) (any, error)) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		body, err := h(appctx.New(c))
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, body)
+	}
+}
@@ -0,0 +1,39 @@
+package example
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	echo "github.com/labstack/echo/v4"
+)
+
+// UserService lists users
+type UserService interface {
+	List() []string
+}
+
+// ListUsers returns a handler listing users
+func ListUsers(svc UserService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.JSON(http.StatusOK, svc.List())
+	}
+}
+
+// CountUsers returns a handler counting users
+func CountUsers(svc UserService) func(*gin.Context) {
+	return func(c *gin.Context) {
+		c.JSON(http.StatusOK, len(svc.List()))
+	}
+}
+
+// EchoListUsers returns an Echo handler listing users
+func EchoListUsers(svc UserService) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		return c.JSON(http.StatusOK, svc.List())
+	}
+}
+
+// NewUserService is not a handler factory
+func NewUserService() UserService {
+	return nil
+}
@@ -0,0 +1,26 @@
+package example
+
+import (
+	"github.com/gofiber/fiber/v2"
+)
+
+// CreateUserRequest is the body of CreateUser
+type CreateUserRequest struct {
+	Name string `json:"name"`
+}
+
+// GetUser handler function
+func GetUser(c *fiber.Ctx) error {
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"id": c.Params("id")})
+}
+
+// CreateUser returns a Fiber handler
+func CreateUser() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		var req CreateUserRequest
+		if err := c.BodyParser(&req); err != nil {
+			return fiber.ErrBadRequest
+		}
+		return c.Status(fiber.StatusCreated).JSON(req)
+	}
+}
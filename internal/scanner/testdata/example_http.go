@@ -0,0 +1,23 @@
+package example
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// GetItem handler function
+func GetItem(w http.ResponseWriter, r *http.Request) {
+	_ = json.NewEncoder(w).Encode(r.PathValue("id"))
+}
+
+// ItemsHandler returns a net/http handler
+func ItemsHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}
+}
+
+// WriteItem is not a handler
+func WriteItem(w http.ResponseWriter, item string) {
+	_, _ = w.Write([]byte(item))
+}
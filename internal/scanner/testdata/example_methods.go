@@ -0,0 +1,38 @@
+package example
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// UserHandler serves the user endpoints
+type UserHandler struct{}
+
+// Get handler method
+func (h *UserHandler) Get(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"id": c.Param("id")})
+}
+
+// list handler method, registered as h.list
+func (h *UserHandler) list(c *gin.Context) {
+	c.JSON(http.StatusOK, []gin.H{})
+}
+
+// render is not registered with any route
+func (h *UserHandler) render(c *gin.Context) {
+	c.String(http.StatusOK, "users")
+}
+
+// OrderHandler serves the order endpoints
+type OrderHandler struct{}
+
+// Get handler method
+func (h OrderHandler) Get(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"id": c.Param("id")})
+}
+
+// Health handler function
+func Health(c *gin.Context) {
+	c.Status(http.StatusOK)
+}
@@ -0,0 +1,14 @@
+//go:build ignore
+
+package example
+
+import (
+	"net/http"
+
+	g "github.com/gin-gonic/gin"
+)
+
+// IgnoredHandler handler function excluded from the build
+func IgnoredHandler(c *g.Context) {
+	c.JSON(http.StatusOK, "ignored")
+}
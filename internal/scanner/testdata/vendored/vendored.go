@@ -0,0 +1,10 @@
+package vendored
+
+import (
+	gin "example.com/vendored/gin"
+)
+
+// NotAHandler takes a Context from an unrelated package named gin
+func NotAHandler(c *gin.Context) {
+	c.Done()
+}
@@ -3,7 +3,7 @@ package test
 // MockOpenAIClient is a mock implementation of the Client interface.
 type MockOpenAIClient struct{}
 
-func (m *MockOpenAIClient) GenerateSwaggerComment(functionName, functionContent, model string, route string) (string, error) {
+func (m *MockOpenAIClient) GenerateSwaggerComment(functionName, functionContent, model string, route string, hints string) (string, error) {
 	return `// ` + functionName + ` godoc
 // @Summary ` + functionName + ` summary
 // @Description do ` + functionName + `